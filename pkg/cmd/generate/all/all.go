@@ -0,0 +1,204 @@
+package all
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/algolia/docli/pkg/cmd/generate/cdn"
+	"github.com/algolia/docli/pkg/cmd/generate/changelog"
+	"github.com/algolia/docli/pkg/cmd/generate/clients"
+	"github.com/algolia/docli/pkg/cmd/generate/guides"
+	"github.com/algolia/docli/pkg/cmd/generate/openapi"
+	"github.com/algolia/docli/pkg/cmd/generate/schemas"
+	"github.com/algolia/docli/pkg/cmd/generate/sla"
+	"github.com/algolia/docli/pkg/cmd/generate/snippets"
+	"github.com/algolia/docli/pkg/config"
+	"github.com/algolia/docli/pkg/output"
+	"github.com/algolia/docli/pkg/validate"
+	"github.com/spf13/cobra"
+)
+
+// Options represents the options and flags for this command.
+type Options struct {
+	ConfigFile string
+	Jobs       []string
+}
+
+// runner runs a single job from the config file.
+type runner func(ctx context.Context, job config.Job, printer *output.Printer) error
+
+// runners maps the generator names in the config file to their commands.
+var runners = map[string]runner{
+	"cdn": func(ctx context.Context, job config.Job, printer *output.Printer) error {
+		opts := &cdn.Options{}
+		if err := decodeOptions(job, cdn.NewCdnCommand(), opts); err != nil {
+			return err
+		}
+
+		return cdn.Run(ctx, opts, printer)
+	},
+	"changelog": withOptions(changelog.NewChangelogCommand, changelog.Run),
+	"clients":   withOptions(clients.NewClientsCommand, clients.Run),
+	"guides":    withOptions(guides.NewGuidesCommand, guides.Run),
+	"openapi":   withOptions(openapi.NewOpenAPICommand, openapi.Run),
+	"schemas":   withOptions(schemas.NewSchemasCommand, schemas.Run),
+	"sla":       withOptions(sla.NewSLACommand, sla.Run),
+	"snippets":  withOptions(snippets.NewSnippetsCommand, snippets.Run),
+}
+
+// NewAllCommand returns a new instance of the `generate all` command.
+func NewAllCommand() *cobra.Command {
+	opts := &Options{}
+
+	cmd := &cobra.Command{
+		Use:   "all",
+		Short: "Run all generation jobs from the project config file",
+		Long: heredoc.Doc(`
+			This command reads the project config file (default: docli.yml)
+			and runs each listed job in order.
+
+			Each job has a generator and the options for it.
+			The generators are the names of the other generate commands:
+			cdn, changelog, clients, guides, openapi, schemas, sla, and snippets.
+			The options have the same names as the command's flags,
+			and options that a job doesn't set have the same defaults.
+			The input file is the 'input' option
+			(for the cdn generator, use the 'data' option,
+			for the changelog generator, the 'base' and 'head' options).
+			Paths are relative to the current directory.
+
+			For example:
+
+			  jobs:
+			    - name: Search API reference
+			      generator: openapi
+			      input: specs/search.yml
+			      output: doc/rest-api
+			      prune: true
+			    - generator: snippets
+			      input: specs/search-snippets.json
+			      output: openapi-snippets/search
+		`),
+		Example: heredoc.Doc(`
+			# Run from root of algolia/docs-new
+			docli gen all
+
+			# Only run some jobs
+			docli gen all --job "Search API reference"
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+
+			printer, err := output.New(cmd)
+			if err != nil {
+				return err
+			}
+
+			return printer.Finish(runCommand(cmd.Context(), opts, printer))
+		},
+	}
+
+	cmd.Flags().
+		StringVarP(&opts.ConfigFile, "config", "c", config.DefaultFilename, "Project config file with the generation jobs")
+	cmd.Flags().
+		StringSliceVar(&opts.Jobs, "job", nil, "Only run the jobs with these names")
+
+	return cmd
+}
+
+// runCommand runs the `generate all` command.
+func runCommand(ctx context.Context, opts *Options, printer *output.Printer) error {
+	if err := validate.ExistingFile(opts.ConfigFile, "config file"); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(opts.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	printer.AddInput(opts.ConfigFile)
+
+	jobs, err := selectJobs(cfg.Jobs, opts.Jobs)
+	if err != nil {
+		return err
+	}
+
+	for i, job := range jobs {
+		run, ok := runners[job.Generator]
+		if !ok {
+			return fmt.Errorf(
+				"job %q: unknown generator %q, use one of: %s",
+				job.Label(),
+				job.Generator,
+				strings.Join(generatorNames(), ", "),
+			)
+		}
+
+		printer.Infof("Running job %d of %d: %s\n", i+1, len(jobs), job.Label())
+
+		if err := run(ctx, job, printer); err != nil {
+			return fmt.Errorf("job %q: %w", job.Label(), err)
+		}
+	}
+
+	return nil
+}
+
+// selectJobs returns the jobs with the given names, or all jobs if no names are given.
+func selectJobs(jobs []config.Job, names []string) ([]config.Job, error) {
+	if len(names) == 0 {
+		return jobs, nil
+	}
+
+	var result []config.Job
+
+	for _, name := range names {
+		i := slices.IndexFunc(jobs, func(job config.Job) bool { return job.Name == name })
+		if i == -1 {
+			return nil, fmt.Errorf("no job named %q in the config file", name)
+		}
+
+		result = append(result, jobs[i])
+	}
+
+	return result, nil
+}
+
+// withOptions returns a runner that decodes the job options for run.
+// newCommand returns the generator's command with the default options.
+func withOptions[T any](newCommand func() *cobra.Command, run func(*T, *output.Printer) error) runner {
+	return func(_ context.Context, job config.Job, printer *output.Printer) error {
+		opts := new(T)
+		if err := decodeOptions(job, newCommand(), opts); err != nil {
+			return err
+		}
+
+		return run(opts, printer)
+	}
+}
+
+// decodeOptions decodes the job options into opts,
+// with the defaults of the command's flags for the options that the job doesn't set.
+func decodeOptions(job config.Job, cmd *cobra.Command, opts any) error {
+	if err := config.SetDefaults(cmd.Flags(), opts); err != nil {
+		return err
+	}
+
+	return job.DecodeOptions(opts)
+}
+
+func generatorNames() []string {
+	names := make([]string, 0, len(runners))
+	for name := range runners {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
@@ -0,0 +1,161 @@
+package all
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/algolia/docli/pkg/cmd/generate/cdn"
+	"github.com/algolia/docli/pkg/cmd/generate/openapi"
+	"github.com/algolia/docli/pkg/cmd/generate/utils"
+	"github.com/algolia/docli/pkg/config"
+	"github.com/algolia/docli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+func TestSelectJobs(t *testing.T) {
+	t.Parallel()
+
+	jobs := []config.Job{
+		{Name: "Search API", Generator: "openapi"},
+		{Name: "Search snippets", Generator: "snippets"},
+		{Generator: "cdn"},
+	}
+
+	got, err := selectJobs(jobs, nil)
+	if err != nil || !reflect.DeepEqual(got, jobs) {
+		t.Fatalf("selectJobs() = %v, %v, want all jobs", got, err)
+	}
+
+	got, err = selectJobs(jobs, []string{"Search snippets", "Search API"})
+	if err != nil {
+		t.Fatalf("selectJobs() error = %v", err)
+	}
+
+	if want := []config.Job{jobs[1], jobs[0]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("selectJobs() = %v, want %v", got, want)
+	}
+
+	if _, err := selectJobs(jobs, []string{"Recommend API"}); err == nil {
+		t.Error("selectJobs() error = nil, want error for unknown job")
+	}
+}
+
+func TestRunCommandRejectsUnknownGenerator(t *testing.T) {
+	t.Parallel()
+
+	configFile := writeFile(t, t.TempDir(), "docli.yml", `jobs:
+  - name: Search API
+    generator: opneapi
+    input: specs/search.yml
+`)
+
+	err := runCommand(context.Background(), &Options{ConfigFile: configFile}, newTestPrinter(t))
+	if err == nil || !strings.Contains(err.Error(), `job "Search API": unknown generator "opneapi", use one of: cdn,`) {
+		t.Fatalf("runCommand() error = %v, want unknown generator error", err)
+	}
+}
+
+func TestRunCommandRunsSelectedJobsWithFlagDefaults(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	writeFile(t, dir, "guides.json", `{"addRecords": {"javascript": "client.saveObjects();"}}`)
+	writeFile(t, dir, "docli.yml", `jobs:
+  - name: Guides
+    generator: guides
+    input: guides.json
+  - name: Other guides
+    generator: guides
+    input: guides.json
+    output: other
+`)
+
+	opts := &Options{ConfigFile: "docli.yml", Jobs: []string{"Guides"}}
+	if err := runCommand(context.Background(), opts, newTestPrinter(t)); err != nil {
+		t.Fatalf("runCommand() error = %v", err)
+	}
+
+	// The job doesn't set the output, so it's the default of the --output flag
+	if _, err := os.Stat(filepath.Join(dir, "out", "add-records.mdx")); err != nil {
+		t.Errorf("Stat() error = %v, want the guide in the default output directory", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "other")); !os.IsNotExist(err) {
+		t.Errorf("Stat() error = %v, want the unselected job not to run", err)
+	}
+}
+
+func TestDecodeOptionsUsesFlagDefaults(t *testing.T) {
+	t.Parallel()
+
+	configFile := writeFile(t, t.TempDir(), "docli.yml", `jobs:
+  - generator: openapi
+    input: specs/search.yml
+  - generator: cdn
+    output: snippets
+`)
+
+	cfg, err := config.Load(configFile)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	openapiOpts := &openapi.Options{}
+	if err := decodeOptions(cfg.Jobs[0], openapi.NewOpenAPICommand(), openapiOpts); err != nil {
+		t.Fatalf("decodeOptions() error = %v", err)
+	}
+
+	if openapiOpts.InputFileName != "specs/search.yml" || openapiOpts.OutputDirectory != "out" ||
+		openapiOpts.OnCollision != utils.OnCollisionFail ||
+		!reflect.DeepEqual(openapiOpts.SkipPaths, utils.DefaultSkipPaths) {
+		t.Errorf("openapi options = %+v, want input from the job and flag defaults", openapiOpts)
+	}
+
+	cdnOpts := &cdn.Options{}
+	if err := decodeOptions(cfg.Jobs[1], cdn.NewCdnCommand(), cdnOpts); err != nil {
+		t.Fatalf("decodeOptions() error = %v", err)
+	}
+
+	want := cdn.Options{
+		DataFile:        "cdn.yml",
+		OutputDirectory: "snippets",
+		TemplateDir:     "templates",
+		OnCollision:     utils.OnCollisionFail,
+	}
+	if *cdnOpts != want {
+		t.Errorf("cdn options = %+v, want %+v", *cdnOpts, want)
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	return path
+}
+
+// newTestPrinter returns a printer with the global flags of the root command.
+func newTestPrinter(t *testing.T) *output.Printer {
+	t.Helper()
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	output.AddFlags(cmd.Flags())
+
+	printer, err := output.New(cmd)
+	if err != nil {
+		t.Fatalf("output.New() error = %v", err)
+	}
+
+	return printer
+}
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/MakeNowJust/heredoc"
+	"github.com/algolia/docli/pkg/cmd/generate/utils"
 	"github.com/algolia/docli/pkg/output"
 	"github.com/algolia/docli/pkg/validate"
 	"github.com/spf13/cobra"
@@ -25,9 +26,10 @@ import (
 
 // Options represents the options and flags for this command.
 type Options struct {
-	DataFile        string
-	OutputDirectory string
-	TemplateDir     string
+	DataFile        string `yaml:"data"`
+	OutputDirectory string `yaml:"output"`
+	TemplateDir     string `yaml:"templates"`
+	OnCollision     string `yaml:"on-collision"`
 }
 
 const (
@@ -385,18 +387,24 @@ func NewCdnCommand() *cobra.Command {
 			Each package name in cdn.yml must match a template name.
 			For example, if the package is autocomplete_js,
 			the command looks for the template file autocomplete_js.mdx.tmpl.
+
+			Packages with the same name have the same output file.
+			The command lists these packages and stops before writing files.
+			Use --on-collision suffix to write the later packages to numbered files instead.
 		`),
 		Example: heredoc.Doc(`
 			# Run from the root of algolia/docs-new
 			docli gen cdn -o include-snippets [-d cdn.yml] [-t templates]
 		`),
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+
 			printer, err := output.New(cmd)
 			if err != nil {
 				return err
 			}
 
-			return runCommand(cmd.Context(), opts, printer)
+			return printer.Finish(Run(cmd.Context(), opts, printer))
 		},
 	}
 
@@ -406,12 +414,14 @@ func NewCdnCommand() *cobra.Command {
 		StringVarP(&opts.TemplateDir, "templates", "t", "templates", "Directory with template files for interpolation.")
 	cmd.Flags().
 		StringVarP(&opts.OutputDirectory, "output", "o", "out", "Output directory for generated files")
+	cmd.Flags().
+		StringVar(&opts.OnCollision, "on-collision", utils.OnCollisionFail, "Handle colliding output files: fail or suffix")
 
 	return cmd
 }
 
-// runCommand runs the `generate openapi` command.
-func runCommand(ctx context.Context, opts *Options, printer *output.Printer) error {
+// Run runs the `generate cdn` command with the given options.
+func Run(ctx context.Context, opts *Options, printer *output.Printer) error {
 	if err := validateOptions(opts); err != nil {
 		return err
 	}
@@ -421,16 +431,30 @@ func runCommand(ctx context.Context, opts *Options, printer *output.Printer) err
 		return fmt.Errorf("read CDN data file %s: %w", opts.DataFile, err)
 	}
 
+	printer.AddInput(opts.DataFile)
+
 	if !printer.IsDryRun() {
 		if err = os.MkdirAll(opts.OutputDirectory, 0o700); err != nil {
 			return fmt.Errorf("create output directory %s: %w", opts.OutputDirectory, err)
 		}
 	}
 
+	plan := utils.NewOutputPlan(opts.OnCollision)
+
+	filenames := make([]string, len(data))
+	for i, pkg := range data {
+		filenames[i] = plan.Add(opts.OutputDirectory, pkg.Name+".mdx", "package "+pkg.Name)
+	}
+
+	if err := plan.Check(printer); err != nil {
+		return err
+	}
+
 	resolver := NewResolver(nil)
 
-	for _, pkg := range data {
-		if err := writePackage(ctx, opts, resolver, printer, pkg); err != nil {
+	for i, pkg := range data {
+		out := filepath.Join(opts.OutputDirectory, filenames[i])
+		if err := writePackage(ctx, opts, resolver, printer, pkg, out); err != nil {
 			return err
 		}
 	}
@@ -447,7 +471,11 @@ func validateOptions(opts *Options) error {
 		return err
 	}
 
-	return validate.OutputDir(opts.OutputDirectory, "output directory")
+	if err := validate.OutputDir(opts.OutputDirectory, "output directory"); err != nil {
+		return err
+	}
+
+	return utils.ValidateOnCollision(opts.OnCollision)
 }
 
 func writePackage(
@@ -456,6 +484,7 @@ func writePackage(
 	resolver *Resolver,
 	printer *output.Printer,
 	pkg PackageSpec,
+	out string,
 ) error {
 	resolved, err := resolver.ResolveWithContext(ctx, pkg)
 	if err != nil {
@@ -467,8 +496,6 @@ func writePackage(
 		return fmt.Errorf("load template for %s: %w", resolved.Name, err)
 	}
 
-	out := filepath.Join(opts.OutputDirectory, resolved.Name+".mdx")
-
 	if err := printer.WriteFile(out, func(w io.Writer) error {
 		return t.Execute(w, resolved)
 	}); err != nil {
@@ -12,6 +12,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/algolia/docli/pkg/output"
 )
 
 type roundTripperFunc func(*http.Request) (*http.Response, error)
@@ -537,3 +539,25 @@ func TestGetTemplateMultipleFiles(t *testing.T) {
 		t.Fatal("expected error when multiple files match pattern, got nil")
 	}
 }
+
+func TestCommandFailsOnCollidingPackages(t *testing.T) {
+	dir := t.TempDir()
+	dataFile := filepath.Join(dir, "cdn.yml")
+	data := "- name: autocomplete_js\n- name: Autocomplete_js\n  pkg: '@algolia/autocomplete-js'\n"
+
+	if err := os.WriteFile(dataFile, []byte(data), 0o600); err != nil {
+		t.Fatalf("unable to write data file: %v", err)
+	}
+
+	cmd := NewCdnCommand()
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	output.AddFlags(cmd.Flags())
+	cmd.SetArgs([]string{"-d", dataFile, "-t", dir, "-o", filepath.Join(dir, "out")})
+
+	// The packages collide before any request to the registry
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "package autocomplete_js, package Autocomplete_js") {
+		t.Fatalf("expected error listing both packages, got %v", err)
+	}
+}
@@ -0,0 +1,292 @@
+package changelog
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/algolia/docli/pkg/cmd/generate/utils"
+	"github.com/algolia/docli/pkg/output"
+	"github.com/algolia/docli/pkg/validate"
+	"github.com/pb33f/libopenapi"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/spf13/cobra"
+)
+
+// Options represents the options and flags for this command.
+type Options struct {
+	BaseFilename string `yaml:"base"`
+	HeadFilename string `yaml:"head"`
+	Output       string `yaml:"output"`
+	JSONOutput   string `yaml:"json"`
+	Date         string `yaml:"date"`
+	TemplatesDir string `yaml:"templates-dir"`
+}
+
+// Changelog lists the changes between two versions of a spec.
+type Changelog struct {
+	Title       string   `json:"title"`
+	Date        string   `json:"date"`
+	BaseVersion string   `json:"baseVersion"`
+	HeadVersion string   `json:"headVersion"`
+	Changes     []Change `json:"changes"`
+}
+
+// Breaking returns the changes that can break existing integrations.
+func (c Changelog) Breaking() []Change {
+	var result []Change
+
+	for _, change := range c.Changes {
+		if change.Breaking {
+			result = append(result, change)
+		}
+	}
+
+	return result
+}
+
+// NonBreaking returns the changes that don't break existing integrations.
+func (c Changelog) NonBreaking() []Change {
+	var result []Change
+
+	for _, change := range c.Changes {
+		if !change.Breaking {
+			result = append(result, change)
+		}
+	}
+
+	return result
+}
+
+const changelogTemplateName = "changelog.mdx.tmpl"
+
+//go:embed changelog.mdx.tmpl
+var changelogTemplate string
+
+// DefaultTemplates returns the built-in templates of this command by file name.
+func DefaultTemplates() map[string]string {
+	return map[string]string{
+		changelogTemplateName: changelogTemplate,
+	}
+}
+
+// NewChangelogCommand returns a new instance of the `generate changelog` command.
+func NewChangelogCommand() *cobra.Command {
+	opts := &Options{}
+
+	cmd := &cobra.Command{
+		Use:   "changelog",
+		Short: "Generate a changelog entry from two versions of an OpenAPI spec",
+		Long: heredoc.Doc(`
+			This command compares two versions of an OpenAPI spec
+			and generates an MDX changelog entry with the changes.
+
+			It lists added, removed, renamed, and moved operations,
+			changes to parameters and to the fields of request bodies and responses,
+			newly deprecated operations, and changes to the ACL (x-acl)
+			and beta status (x-beta) of operations.
+			Operations are matched by method and path,
+			or by operation ID if their path changed.
+
+			Changes that can break existing integrations,
+			such as removed operations or new required parameters,
+			are listed separately as breaking changes.
+
+			Without --output, the entry is printed to stdout,
+			so use --report-file instead of --report for a run report.
+			Use --json to also write the changes as JSON for other tools.
+
+			To change the generated entry, use --templates-dir with a directory
+			that has your own changelog.mdx.tmpl template.
+			Run 'docli templates export' to start from the built-in template.
+		`),
+		Example: heredoc.Doc(`
+			# Compare the spec from the main branch with the current one
+			git show main:specs/search.yml > /tmp/search-main.yml
+			docli gen changelog --base /tmp/search-main.yml --head specs/search.yml \
+			  -o changelog/search.mdx --json changelog/search.json
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+
+			printer, err := output.New(cmd)
+			if err != nil {
+				return err
+			}
+
+			return printer.Finish(Run(opts, printer))
+		},
+	}
+
+	cmd.Flags().
+		StringVar(&opts.BaseFilename, "base", "", "Previous version of the OpenAPI spec")
+	cmd.Flags().
+		StringVar(&opts.HeadFilename, "head", "", "New version of the OpenAPI spec")
+	cmd.Flags().
+		StringVarP(&opts.Output, "output", "o", "", "MDX file for the changelog entry")
+	cmd.Flags().
+		StringVar(&opts.JSONOutput, "json", "", "JSON file for the list of changes")
+	cmd.Flags().
+		StringVar(&opts.Date, "date", "", "Date of the changelog entry (default today, as YYYY-MM-DD)")
+	cmd.Flags().
+		StringVar(&opts.TemplatesDir, "templates-dir", "", "Directory with templates that replace the built-in ones")
+
+	return cmd
+}
+
+// Run runs the `generate changelog` command with the given options.
+func Run(opts *Options, printer *output.Printer) error {
+	if opts.Date == "" {
+		opts.Date = time.Now().Format(time.DateOnly)
+	}
+
+	if err := validateOptions(opts, printer.IsDryRun()); err != nil {
+		return err
+	}
+
+	printer.Infof("Comparing %s with %s\n", opts.BaseFilename, opts.HeadFilename)
+
+	log, err := buildChangelog(opts, printer)
+	if err != nil {
+		return err
+	}
+
+	breaking := len(log.Breaking())
+	printer.Infof("Found %d changes, %d of them breaking\n", len(log.Changes), breaking)
+
+	tmpl, err := utils.LoadTemplate(opts.TemplatesDir, changelogTemplateName, changelogTemplate)
+	if err != nil {
+		return err
+	}
+
+	if opts.Output == "" {
+		stdout, err := printer.Stdout("changelog entry")
+		if err != nil {
+			return err
+		}
+
+		if err := tmpl.Execute(stdout, log); err != nil {
+			return fmt.Errorf("render changelog: %w", err)
+		}
+	} else {
+		err := printer.WriteFile(opts.Output, func(w io.Writer) error {
+			if err := tmpl.Execute(w, log); err != nil {
+				return fmt.Errorf("render changelog: %w", err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.JSONOutput == "" {
+		return nil
+	}
+
+	return printer.WriteFile(opts.JSONOutput, func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(log)
+	})
+}
+
+// buildChangelog loads both specs and compares them.
+func buildChangelog(opts *Options, printer *output.Printer) (Changelog, error) {
+	base, err := loadSpec(opts.BaseFilename, printer)
+	if err != nil {
+		return Changelog{}, err
+	}
+
+	head, err := loadSpec(opts.HeadFilename, printer)
+	if err != nil {
+		return Changelog{}, err
+	}
+
+	changes, err := diffSpecs(&base.Model, &head.Model)
+	if err != nil {
+		return Changelog{}, err
+	}
+
+	log := Changelog{
+		Date: opts.Date,
+		// Tools reading the JSON get an empty list instead of null
+		Changes: append([]Change{}, changes...),
+	}
+
+	if info := base.Model.Info; info != nil {
+		log.BaseVersion = info.Version
+	}
+
+	if info := head.Model.Info; info != nil {
+		log.Title = info.Title
+		log.HeadVersion = info.Version
+	}
+
+	return log, nil
+}
+
+func loadSpec(filename string, printer *output.Printer) (*libopenapi.DocumentModel[v3.Document], error) {
+	specFile, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("read spec %s: %w", filename, err)
+	}
+
+	printer.AddInput(filename)
+
+	doc, err := utils.LoadSpec(specFile)
+	if err != nil {
+		return nil, fmt.Errorf("load spec %s: %w", filename, err)
+	}
+
+	return doc, nil
+}
+
+func validateOptions(opts *Options, dryRun bool) error {
+	if err := validate.ExistingFile(opts.BaseFilename, "base spec"); err != nil {
+		return err
+	}
+
+	if err := validate.ExistingFile(opts.HeadFilename, "head spec"); err != nil {
+		return err
+	}
+
+	if _, err := time.Parse(time.DateOnly, opts.Date); err != nil {
+		return fmt.Errorf("invalid date %q, use YYYY-MM-DD: %w", opts.Date, err)
+	}
+
+	if opts.TemplatesDir != "" {
+		if err := validate.ExistingDir(opts.TemplatesDir, "templates directory"); err != nil {
+			return err
+		}
+	}
+
+	if opts.Output != "" {
+		if err := validateOutputFile(opts.Output, "output file", dryRun); err != nil {
+			return err
+		}
+	}
+
+	if opts.JSONOutput != "" {
+		if err := validateOutputFile(opts.JSONOutput, "JSON file", dryRun); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateOutputFile(path, label string, dryRun bool) error {
+	if dryRun {
+		return validate.OutputFileDryRun(path, label)
+	}
+
+	return validate.OutputFile(path, label)
+}
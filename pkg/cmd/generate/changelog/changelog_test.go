@@ -0,0 +1,287 @@
+package changelog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/algolia/docli/pkg/cmd/generate/utils"
+)
+
+const baseSpec = `openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+paths:
+  /{path}:
+    get:
+      operationId: customGet
+  /1/indexes/{indexName}/query:
+    post:
+      operationId: searchSingleIndex
+      x-acl: [search]
+      parameters:
+        - name: indexName
+          in: path
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                query:
+                  type: string
+                page:
+                  type: integer
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  hits:
+                    type: array
+                    items:
+                      type: object
+                      properties:
+                        objectID:
+                          type: string
+                        _highlightResult:
+                          type: object
+                  nbHits:
+                    type: integer
+  /1/indexes/{indexName}/settings:
+    get:
+      operationId: getSettings
+      x-acl: [settings]
+  /1/indexes/{indexName}/rules/search:
+    post:
+      operationId: searchRules
+      x-acl: [settings]
+  /1/indexes/{indexName}/synonyms:
+    get:
+      operationId: getSynonyms
+      parameters:
+        - name: type
+          in: query
+          schema:
+            type: string
+            enum: [synonym, onewaysynonym, altcorrection1]
+  /1/logs:
+    get:
+      operationId: getLogs
+      x-acl: [logs]
+`
+
+const headSpec = `openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.1.0
+paths:
+  /{path}:
+    get:
+      operationId: customGetRequest
+  /1/indexes/{indexName}/query:
+    post:
+      operationId: searchSingleIndex
+      x-acl: [search]
+      parameters:
+        - name: indexName
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: x-algolia-user-id
+          in: header
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [query]
+              properties:
+                query:
+                  type: string
+                page:
+                  type: string
+                hitsPerPage:
+                  type: integer
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  hits:
+                    type: array
+                    items:
+                      type: object
+                      properties:
+                        objectID:
+                          type: string
+                  nbHits:
+                    type: integer
+                  processingTimeMS:
+                    type: integer
+  /1/indexes/{indexName}/settings:
+    get:
+      operationId: getIndexSettings
+      deprecated: true
+      x-acl: [settings, editSettings]
+      x-beta: true
+  /1/indexes/{indexName}/rules/query:
+    post:
+      operationId: searchRules
+      x-acl: [settings]
+  /1/indexes/{indexName}/synonyms:
+    get:
+      operationId: getSynonyms
+      parameters:
+        - name: type
+          in: query
+          required: true
+          schema:
+            type: string
+            enum: [synonym, onewaysynonym, placeholder]
+  /1/tasks:
+    get:
+      operationId: listTasks
+`
+
+func TestDiffSpecs(t *testing.T) {
+	base, err := utils.LoadSpec([]byte(baseSpec))
+	if err != nil {
+		t.Fatalf("LoadSpec() base error = %v", err)
+	}
+
+	head, err := utils.LoadSpec([]byte(headSpec))
+	if err != nil {
+		t.Fatalf("LoadSpec() head error = %v", err)
+	}
+
+	changes, err := diffSpecs(&base.Model, &head.Model)
+	if err != nil {
+		t.Fatalf("diffSpecs() error = %v", err)
+	}
+
+	type summary struct {
+		kind     string
+		id       string
+		name     string
+		breaking bool
+	}
+
+	want := []summary{
+		{KindParameterAdded, "searchSingleIndex", "x-algolia-user-id", false},
+		{KindFieldChanged, "searchSingleIndex", "query", true},
+		{KindFieldChanged, "searchSingleIndex", "page", true},
+		{KindFieldAdded, "searchSingleIndex", "hitsPerPage", false},
+		{KindFieldAdded, "searchSingleIndex", "processingTimeMS", false},
+		{KindFieldRemoved, "searchSingleIndex", "hits._highlightResult", true},
+		{KindOperationRenamed, "getIndexSettings", "", false},
+		{KindOperationDeprecated, "getIndexSettings", "", false},
+		{KindBetaChanged, "getIndexSettings", "", false},
+		{KindACLChanged, "getIndexSettings", "", true},
+		{KindOperationMoved, "searchRules", "", true},
+		{KindParameterChanged, "getSynonyms", "type", true},
+		{KindOperationAdded, "listTasks", "", false},
+		{KindOperationRemoved, "getLogs", "", true},
+	}
+
+	got := make([]summary, len(changes))
+	for i, c := range changes {
+		got[i] = summary{c.Kind, c.OperationID, c.Name, c.Breaking}
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("diffSpecs() returned %d changes, want %d:\n%+v", len(got), len(want), got)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	wantMessages := map[int]string{
+		1:  "Changed request body field `query`: now required.",
+		2:  "Changed request body field `page`: type changed from `integer` to `string`.",
+		9:  "Changed the required ACL from `settings` to `settings`, `editSettings`.",
+		10: "Moved from `POST /1/indexes/{indexName}/rules/search`.",
+		11: "Changed query parameter `type`: now required, removed values `altcorrection1`, new values `placeholder`.",
+	}
+
+	for i, message := range wantMessages {
+		if changes[i].Message != message {
+			t.Errorf("change %d message = %q, want %q", i, changes[i].Message, message)
+		}
+	}
+}
+
+func TestRenderChangelog(t *testing.T) {
+	t.Parallel()
+
+	tmpl, err := utils.LoadTemplate("", changelogTemplateName, changelogTemplate)
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+
+	log := Changelog{
+		Title:       "Search API",
+		Date:        "2025-06-02",
+		BaseVersion: "1.0.0",
+		HeadVersion: "1.1.0",
+		Changes: []Change{
+			{
+				Kind:        KindOperationAdded,
+				OperationID: "listTasks",
+				Method:      "GET",
+				Path:        "/1/tasks",
+				Message:     "New operation.",
+			},
+			{
+				Kind:        KindOperationRemoved,
+				Breaking:    true,
+				OperationID: "getLogs",
+				Method:      "GET",
+				Path:        "/1/logs",
+				Message:     "Removed operation.",
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, log); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		`<Update label="2025-06-02" description="Search API 1.1.0">`,
+		``,
+		`Changes from version 1.0.0 to 1.1.0.`,
+		``,
+		`### Breaking changes`,
+		``,
+		"- `GET /1/logs` (getLogs): Removed operation.",
+		``,
+		`### Changes`,
+		``,
+		"- `GET /1/tasks` (listTasks): New operation.",
+		``,
+		`</Update>`,
+		``,
+	}, "\n")
+
+	if buf.String() != want {
+		t.Errorf("rendered changelog =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
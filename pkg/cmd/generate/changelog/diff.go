@@ -0,0 +1,468 @@
+package changelog
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/algolia/docli/pkg/cmd/generate/utils"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// Kinds of changes between two specs.
+const (
+	KindOperationAdded      = "operation-added"
+	KindOperationRemoved    = "operation-removed"
+	KindOperationRenamed    = "operation-renamed"
+	KindOperationMoved      = "operation-moved"
+	KindOperationDeprecated = "operation-deprecated"
+	KindACLChanged          = "acl-changed"
+	KindBetaChanged         = "beta-changed"
+	KindParameterAdded      = "parameter-added"
+	KindParameterRemoved    = "parameter-removed"
+	KindParameterChanged    = "parameter-changed"
+	KindFieldAdded          = "field-added"
+	KindFieldRemoved        = "field-removed"
+	KindFieldChanged        = "field-changed"
+)
+
+// Locations of schema fields.
+const (
+	locationRequest  = "request body"
+	locationResponse = "response"
+)
+
+// Change is a single difference between two versions of a spec.
+type Change struct {
+	Kind     string `json:"kind"`
+	Breaking bool   `json:"breaking"`
+	// OperationID is the ID of the operation in the new spec, or in the old spec for removed operations.
+	OperationID string `json:"operationId"`
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	// Location is where the changed parameter or field is, for example, `query` or `request body`.
+	Location string `json:"location,omitempty"`
+	// Name is the name of the changed parameter, or the dotted path of the changed field.
+	Name    string `json:"name,omitempty"`
+	Message string `json:"message"`
+}
+
+// operation is the part of an operation that's compared between specs.
+type operation struct {
+	id         string
+	method     string
+	path       string
+	deprecated bool
+	beta       bool
+	acl        []string
+	params     []field
+	request    []field
+	response   []field
+}
+
+// field is a parameter or a flattened schema field.
+type field struct {
+	location string
+	name     string
+	schema   utils.SchemaField
+}
+
+func (o operation) key() string {
+	return o.method + " " + o.path
+}
+
+func (o operation) change(kind string, breaking bool, message string) Change {
+	return Change{
+		Kind:        kind,
+		Breaking:    breaking,
+		OperationID: o.id,
+		Method:      o.method,
+		Path:        o.path,
+		Message:     message,
+	}
+}
+
+// diffSpecs returns the changes from the base spec to the head spec.
+// Operations are matched by method and path, and then by operation ID to find moved operations.
+func diffSpecs(base, head *v3.Document) ([]Change, error) {
+	baseOps, err := getOperations(base)
+	if err != nil {
+		return nil, fmt.Errorf("base spec: %w", err)
+	}
+
+	headOps, err := getOperations(head)
+	if err != nil {
+		return nil, fmt.Errorf("head spec: %w", err)
+	}
+
+	headKeys := make(map[string]bool, len(headOps))
+	for _, op := range headOps {
+		headKeys[op.key()] = true
+	}
+
+	matched := make([]bool, len(baseOps))
+
+	var changes []Change
+
+	for _, h := range headOps {
+		i := slices.IndexFunc(baseOps, func(b operation) bool { return b.key() == h.key() })
+
+		switch {
+		case i != -1:
+			if baseOps[i].id != h.id {
+				changes = append(changes, h.change(KindOperationRenamed, false,
+					fmt.Sprintf("Renamed operation ID from `%s` to `%s`.", baseOps[i].id, h.id)))
+			}
+		default:
+			// An operation with the same ID at a path that no longer exists was moved
+			i = slices.IndexFunc(baseOps, func(b operation) bool {
+				return b.id != "" && b.id == h.id && !headKeys[b.key()]
+			})
+			if i == -1 || matched[i] {
+				changes = append(changes, h.change(KindOperationAdded, false, "New operation."))
+
+				continue
+			}
+
+			changes = append(changes, h.change(KindOperationMoved, true,
+				fmt.Sprintf("Moved from `%s`.", baseOps[i].key())))
+		}
+
+		matched[i] = true
+
+		changes = append(changes, compareOperations(baseOps[i], h)...)
+	}
+
+	for i, b := range baseOps {
+		if !matched[i] {
+			changes = append(changes, b.change(KindOperationRemoved, true, "Removed operation."))
+		}
+	}
+
+	return changes, nil
+}
+
+// compareOperations returns the changes between two versions of the same operation.
+func compareOperations(b, h operation) []Change {
+	var changes []Change
+
+	if !b.deprecated && h.deprecated {
+		changes = append(changes, h.change(KindOperationDeprecated, false, "Deprecated."))
+	}
+
+	if b.beta != h.beta {
+		message := "Now in beta."
+		if !h.beta {
+			message = "No longer in beta."
+		}
+
+		changes = append(changes, h.change(KindBetaChanged, false, message))
+	}
+
+	if !slices.Equal(sortedACL(b.acl), sortedACL(h.acl)) {
+		// New ACL can make existing API keys fail
+		added := slices.ContainsFunc(h.acl, func(acl string) bool { return !slices.Contains(b.acl, acl) })
+
+		changes = append(changes, h.change(KindACLChanged, added,
+			fmt.Sprintf("Changed the required ACL from %s to %s.", aclString(b.acl), aclString(h.acl))))
+	}
+
+	changes = append(changes, compareFields(h, b.params, h.params, true)...)
+	changes = append(changes, compareFields(h, b.request, h.request, true)...)
+	changes = append(changes, compareFields(h, b.response, h.response, false)...)
+
+	return changes
+}
+
+// compareFields returns the added, removed, and changed parameters or fields.
+// Input fields are sent by API clients: new required fields break requests.
+// Output fields are received by API clients: removed fields break responses.
+func compareFields(op operation, base, head []field, input bool) []Change {
+	var changes []Change
+
+	for _, h := range head {
+		i := slices.IndexFunc(base, func(b field) bool { return b.location == h.location && b.name == h.name })
+		if i == -1 {
+			changes = append(changes, fieldChange(op, h, addedKind(h), input && h.schema.Required,
+				fmt.Sprintf("New %s%s `%s`.", requiredPrefix(h, input), fieldLabel(h), h.name)))
+
+			continue
+		}
+
+		if c, ok := compareField(op, base[i], h, input); ok {
+			changes = append(changes, c)
+		}
+	}
+
+	for _, b := range base {
+		if !slices.ContainsFunc(head, func(h field) bool { return b.location == h.location && b.name == h.name }) {
+			changes = append(changes, fieldChange(op, b, removedKind(b), true,
+				fmt.Sprintf("Removed %s `%s`.", fieldLabel(b), b.name)))
+		}
+	}
+
+	return changes
+}
+
+// compareField returns the change between two versions of a parameter or field, if there's one.
+func compareField(op operation, b, h field, input bool) (Change, bool) {
+	var (
+		details  []string
+		breaking bool
+	)
+
+	if b.schema.Type != h.schema.Type {
+		details = append(details, fmt.Sprintf("type changed from `%s` to `%s`", b.schema.Type, h.schema.Type))
+		breaking = true
+	}
+
+	if input && b.schema.Required != h.schema.Required {
+		if h.schema.Required {
+			details = append(details, "now required")
+			breaking = true
+		} else {
+			details = append(details, "no longer required")
+		}
+	}
+
+	if removed := missing(b.schema.Enum, h.schema.Enum); len(removed) > 0 {
+		details = append(details, "removed values "+codeList(removed))
+		breaking = breaking || input
+	}
+
+	if added := missing(h.schema.Enum, b.schema.Enum); len(added) > 0 {
+		details = append(details, "new values "+codeList(added))
+	}
+
+	if !b.schema.Deprecated && h.schema.Deprecated {
+		details = append(details, "deprecated")
+	}
+
+	if len(details) == 0 {
+		return Change{}, false
+	}
+
+	return fieldChange(op, h, changedKind(h), breaking,
+		fmt.Sprintf("Changed %s `%s`: %s.", fieldLabel(h), h.name, strings.Join(details, ", "))), true
+}
+
+func fieldChange(op operation, f field, kind string, breaking bool, message string) Change {
+	c := op.change(kind, breaking, message)
+	c.Location = f.location
+	c.Name = f.name
+
+	return c
+}
+
+func isParameter(f field) bool {
+	return f.location != locationRequest && f.location != locationResponse
+}
+
+func addedKind(f field) string {
+	if isParameter(f) {
+		return KindParameterAdded
+	}
+
+	return KindFieldAdded
+}
+
+func removedKind(f field) string {
+	if isParameter(f) {
+		return KindParameterRemoved
+	}
+
+	return KindFieldRemoved
+}
+
+func changedKind(f field) string {
+	if isParameter(f) {
+		return KindParameterChanged
+	}
+
+	return KindFieldChanged
+}
+
+// fieldLabel returns a description such as `query parameter` or `response field`.
+func fieldLabel(f field) string {
+	if isParameter(f) {
+		return f.location + " parameter"
+	}
+
+	return f.location + " field"
+}
+
+func requiredPrefix(f field, input bool) string {
+	if input && f.schema.Required {
+		return "required "
+	}
+
+	return ""
+}
+
+// missing returns the values of a that aren't in b.
+func missing(a, b []string) []string {
+	var result []string
+
+	for _, value := range a {
+		if !slices.Contains(b, value) {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
+func sortedACL(acl []string) []string {
+	sorted := slices.Clone(acl)
+	slices.Sort(sorted)
+
+	return sorted
+}
+
+func aclString(acl []string) string {
+	if len(acl) == 0 {
+		return "none"
+	}
+
+	return codeList(acl)
+}
+
+// codeList returns the values as comma-separated inline code.
+func codeList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "`" + value + "`"
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// getOperations returns the operations of the spec that are compared.
+// Custom request paths and operations with `x-docli-skip` aren't documented, so they're ignored.
+func getOperations(doc *v3.Document) ([]operation, error) {
+	if doc.Paths == nil {
+		return nil, nil
+	}
+
+	betaAPI, err := utils.IsBetaAPI(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []operation
+
+	for pathPairs := doc.Paths.PathItems.First(); pathPairs != nil; pathPairs = pathPairs.Next() {
+		pathName, pathItem := pathPairs.Key(), pathPairs.Value()
+
+		for opPairs := pathItem.GetOperations().First(); opPairs != nil; opPairs = opPairs.Next() {
+			op := opPairs.Value()
+
+			skip, err := utils.IsSkipped(pathName, pathItem, op, utils.DefaultSkipPaths)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", opPairs.Key(), pathName, err)
+			}
+
+			if skip {
+				continue
+			}
+
+			data, err := newOperation(strings.ToUpper(opPairs.Key()), pathName, pathItem, op)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", opPairs.Key(), pathName, err)
+			}
+
+			data.beta = data.beta || betaAPI
+			result = append(result, data)
+		}
+	}
+
+	return result, nil
+}
+
+func newOperation(method, pathName string, pathItem *v3.PathItem, op *v3.Operation) (operation, error) {
+	beta, err := utils.IsBetaOperation(op)
+	if err != nil {
+		return operation{}, err
+	}
+
+	acl, err := utils.GetACL(op)
+	if err != nil {
+		return operation{}, err
+	}
+
+	deprecatedBy, err := utils.GetDeprecatedBy(op)
+	if err != nil {
+		return operation{}, err
+	}
+
+	result := operation{
+		id:         op.OperationId,
+		method:     method,
+		path:       pathName,
+		deprecated: (op.Deprecated != nil && *op.Deprecated) || deprecatedBy != "",
+		beta:       beta,
+		acl:        acl,
+		params:     getParameters(pathItem, op),
+	}
+
+	if op.RequestBody != nil {
+		if schema := utils.JSONSchema(op.RequestBody.Content); schema != nil {
+			flattenFields(&result.request, locationRequest, "", utils.NewSchemaField("", schema, false).Fields)
+		}
+	}
+
+	if response := successResponse(op); response != nil {
+		if schema := utils.JSONSchema(response.Content); schema != nil {
+			flattenFields(&result.response, locationResponse, "", utils.NewSchemaField("", schema, false).Fields)
+		}
+	}
+
+	return result, nil
+}
+
+// getParameters returns the parameters of the operation and its path.
+func getParameters(pathItem *v3.PathItem, op *v3.Operation) []field {
+	params := utils.Parameters(pathItem, op)
+	result := make([]field, 0, len(params))
+
+	for _, param := range params {
+		result = append(result, field{location: param.In, name: param.Name, schema: utils.NewParameterField(param)})
+	}
+
+	return result
+}
+
+// successResponse returns the first 2xx response of the operation.
+func successResponse(op *v3.Operation) *v3.Response {
+	if op.Responses == nil || op.Responses.Codes == nil {
+		return nil
+	}
+
+	for pair := op.Responses.Codes.First(); pair != nil; pair = pair.Next() {
+		if strings.HasPrefix(pair.Key(), "2") {
+			return pair.Value()
+		}
+	}
+
+	return nil
+}
+
+// flattenFields adds the fields and their nested fields with dotted paths, such as `hits.objectID`.
+// Fields of array items use the name of the array.
+func flattenFields(result *[]field, location, prefix string, fields []utils.SchemaField) {
+	for _, f := range fields {
+		name := f.Name
+		if prefix != "" {
+			name = prefix + "." + f.Name
+		}
+
+		// A field can appear more than once in allOf schemas
+		if slices.ContainsFunc(*result, func(r field) bool { return r.name == name }) {
+			continue
+		}
+
+		*result = append(*result, field{location: location, name: name, schema: f})
+
+		flattenFields(result, location, name, f.Fields)
+	}
+}
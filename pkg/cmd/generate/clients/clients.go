@@ -21,9 +21,15 @@ import (
 
 // Options represents configuration options and CLI flags for this command.
 type Options struct {
-	APIName         string
-	InputFilename   string
-	OutputDirectory string
+	APIName         string `yaml:"api-name"`
+	InputFilename   string `yaml:"input"`
+	OutputDirectory string `yaml:"output"`
+	Prune           bool   `yaml:"prune"`
+	TemplatesDir    string `yaml:"templates-dir"`
+	LanguageTabs    bool   `yaml:"language-tabs"`
+	OnCollision     string `yaml:"on-collision"`
+	// The filter options select the operations to generate.
+	utils.OperationFilter `yaml:",inline"`
 }
 
 // ExternalDocs holds an externalDocs reference.
@@ -34,20 +40,25 @@ type ExternalDocs struct {
 
 // OperationData represents relevant information about an API operation.
 type OperationData struct {
-	ACL              string
-	APIName          string
-	Beta             bool
-	CodeSamples      []CodeSample
-	Deprecated       bool
-	Description      string
-	ExternalDocs     ExternalDocs
-	InputFilename    string
-	OutputFilename   string
-	OutputPath       string
-	OperationIDKebab string
-	Params           []Parameter
-	RequestBody      RequestBody
+	ACL            string
+	APIName        string
+	Beta           bool
+	CodeSamples    []CodeSample
+	Deprecated     bool
+	Description    string
+	Errors         []Response
+	ExternalDocs   ExternalDocs
+	InputFilename  string
+	LanguageTabs   bool
+	OutputFilename string
+	OutputPath     string
+	Params         []ParameterGroup
+	RequestBody    RequestBody
+	// ReferencePage is the page of the operation in the REST API reference.
+	// The openapi command plans the same filenames, also with --on-collision suffix.
+	ReferencePage    string
 	RequiresAdmin    bool
+	Responses        []Response
 	SeeAlso          bool
 	ShortDescription string
 	Summary          string
@@ -57,22 +68,59 @@ type CodeSample struct {
 	Lang   string
 	Label  string
 	Source string
+	// Method is the name of the method in the API client of this language.
+	Method string `yaml:"-"`
+	// Signature shows how to call the method with its required arguments.
+	Signature string `yaml:"-"`
 }
 
-type Parameter struct {
+// ParameterGroup holds the parameters of an operation with the same location.
+type ParameterGroup struct {
+	// Location is where the parameter goes: path, query, or header.
+	Location string
+	Title    string
+	Params   []utils.SchemaField
+}
+
+// parameterLocations lists the supported parameter locations in the order of the docs.
+var parameterLocations = []struct {
+	location string
+	title    string
+}{
+	{"path", "Path parameters"},
+	{"query", "Query parameters"},
+	{"header", "Header parameters"},
+}
+
+// RequestBody describes the request body of an operation.
+type RequestBody struct {
 	Name        string
 	Description string
 	Required    bool
+	// Schema holds the fields of the request body.
+	Schema utils.SchemaField
 }
 
-type RequestBody struct {
-	Name        string
+// Response describes a documented response of an operation.
+type Response struct {
+	// Code is the HTTP status code, or `default`.
+	Code        string
 	Description string
+	Schema      utils.SchemaField
 }
 
+const methodTemplateName = "method.mdx.tmpl"
+
 //go:embed method.mdx.tmpl
 var methodTemplate string
 
+// DefaultTemplates returns the built-in templates of this command by file name.
+func DefaultTemplates() map[string]string {
+	return map[string]string{
+		methodTemplateName: methodTemplate,
+	}
+}
+
 func NewClientsCommand() *cobra.Command {
 	opts := &Options{}
 
@@ -84,8 +132,47 @@ func NewClientsCommand() *cobra.Command {
 			This command reads an OpenAPI 3 spec file and generates one MDX file per operation.
 			It writes an API reference with usage information specific to API clients,
 			which may follow different conventions depending on the programming language used.
-			This command doesn't delete MDX files. If you remove or rename an operation,
-			you need to update or delete its MDX file manually.
+
+			To add hand-written content to a generated page, put it between
+			{/* docli:keep-start */} and {/* docli:keep-end */} comments.
+			The command keeps these sections after the same generated line when it updates the page.
+			If that line no longer exists, the section moves to the end of the page with a warning.
+
+			The command records the generated files in a manifest file (.docli-manifest.clients.json).
+			With --prune, it deletes generated MDX files for operations that no longer exist,
+			for example, after removing or renaming an operation.
+			Files that aren't listed in the manifest are never deleted.
+
+			To generate only some operations, use --include-tag, --exclude-tag,
+			or --operation with glob patterns for operation IDs, such as 'get*'.
+			Pages of the other operations stay in the manifest and aren't pruned.
+			To keep internal operations out of the docs, use --exclude-extension,
+			for example, --exclude-extension x-internal.
+			Operations with an excluded extension are pruned.
+
+			Operations at the path /{path}, which is for custom HTTP requests, don't get a page.
+			Use --skip-path to change the skipped paths.
+			To skip other operations, add an x-docli-skip: true extension to the operation or its path.
+
+			Operation IDs that only differ in case or separators, such as getObject and get_object,
+			have the same output file. The command lists these operations and stops before writing files.
+			Use --on-collision suffix to write the later pages to numbered files instead,
+			such as get-object-2.mdx.
+
+			The pages go into a directory named after the API.
+			The API name is the name of the spec file, unless the spec has an x-docli-api-name extension.
+			Use --api-name to set the API name.
+
+			With --language-tabs, the usage section has one tab per language
+			with the method signature and the code sample for that language.
+			Method names follow the naming convention of each language,
+			for example, search_single_index in Python or SearchSingleIndex in Go.
+			To use a different name, add an x-method-name extension to the operation,
+			which maps languages to method names.
+
+			To change the generated pages, use --templates-dir with a directory
+			that has your own method.mdx.tmpl template.
+			Run 'docli templates export' to start from the built-in template.
 		`),
 		Example: heredoc.Doc(`
 			# Run from root of algolia/docs-new
@@ -93,25 +180,49 @@ func NewClientsCommand() *cobra.Command {
 		`),
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
 			opts.InputFilename = args[0]
-			opts.APIName = utils.GetAPIName(opts.InputFilename)
 
 			printer, err := output.New(cmd)
 			if err != nil {
 				return err
 			}
 
-			return runCommand(opts, printer)
+			return printer.Finish(Run(opts, printer))
 		},
 	}
 
 	cmd.Flags().
 		StringVarP(&opts.OutputDirectory, "output", "o", "out", "Output directory for generated MDX files")
+	cmd.Flags().
+		BoolVar(&opts.Prune, "prune", false, "Delete generated MDX files for operations that no longer exist")
+	cmd.Flags().
+		StringVar(&opts.TemplatesDir, "templates-dir", "", "Directory with templates that replace the built-in ones")
+	cmd.Flags().
+		BoolVar(&opts.LanguageTabs, "language-tabs", false, "Show usage in one tab per language with its method name")
+	cmd.Flags().
+		StringSliceVar(&opts.IncludeTags, "include-tag", nil, "Only generate operations with one of these tags")
+	cmd.Flags().
+		StringSliceVar(&opts.ExcludeTags, "exclude-tag", nil, "Don't generate operations with one of these tags")
+	cmd.Flags().
+		StringSliceVar(&opts.Operations, "operation", nil, "Only generate operations with IDs matching these glob patterns")
+	cmd.Flags().
+		StringSliceVar(&opts.ExcludeExtensions, "exclude-extension", nil, "Don't generate operations with these extensions")
+	cmd.Flags().
+		StringSliceVar(&opts.SkipPaths, "skip-path", utils.DefaultSkipPaths, "Don't generate operations for these paths")
+	cmd.Flags().
+		StringVar(&opts.APIName, "api-name", "", "Name of the API for the output directory (default: from the spec)")
+	cmd.Flags().
+		StringVar(&opts.OnCollision, "on-collision", utils.OnCollisionFail, "Handle colliding output files: fail or suffix")
 
 	return cmd
 }
 
-func runCommand(opts *Options, printer *output.Printer) error {
+// Run runs the `generate clients` command with the given options.
+// The API name defaults to the x-docli-api-name extension of the spec,
+// or to the name of the spec file.
+func Run(opts *Options, printer *output.Printer) error {
 	if err := validate.ExistingFile(opts.InputFilename, "spec file"); err != nil {
 		return err
 	}
@@ -120,11 +231,27 @@ func runCommand(opts *Options, printer *output.Printer) error {
 		return err
 	}
 
+	if opts.TemplatesDir != "" {
+		if err := validate.ExistingDir(opts.TemplatesDir, "templates directory"); err != nil {
+			return err
+		}
+	}
+
+	if err := opts.OperationFilter.Validate(); err != nil {
+		return err
+	}
+
+	if err := utils.ValidateOnCollision(opts.OnCollision); err != nil {
+		return err
+	}
+
 	specFile, err := os.ReadFile(opts.InputFilename)
 	if err != nil {
 		return fmt.Errorf("read spec file %s: %w", opts.InputFilename, err)
 	}
 
+	printer.AddInput(opts.InputFilename)
+
 	printer.Infof("Generating API client references for spec: %s\n", opts.InputFilename)
 	printer.Infof("Writing output in: %s\n", opts.OutputDirectory)
 
@@ -133,34 +260,56 @@ func runCommand(opts *Options, printer *output.Printer) error {
 		return fmt.Errorf("load spec %s: %w", opts.InputFilename, err)
 	}
 
-	opData, err := getAPIData(spec, opts)
+	opts.APIName, err = utils.ResolveAPIName(&spec.Model, opts.APIName, opts.InputFilename)
+	if err != nil {
+		return err
+	}
+
+	plan := utils.NewOutputPlan(opts.OnCollision)
+
+	opData, err := getAPIData(spec, opts, plan)
 	if err != nil {
 		return fmt.Errorf("parse spec %s: %w", opts.InputFilename, err)
 	}
 
+	if err := plan.Check(printer); err != nil {
+		return err
+	}
+
 	printer.Verbosef("Spec %s has %d operations.\n", opts.InputFilename, len(opData))
 
-	tmpl := template.Must(template.New("method").Funcs(template.FuncMap{
-		"frontmatterString": utils.QuoteFrontmatterString,
-		"trim":              strings.TrimSpace,
-	}).Parse(methodTemplate))
+	tmpl, err := utils.LoadTemplate(opts.TemplatesDir, methodTemplateName, methodTemplate)
+	if err != nil {
+		return err
+	}
 
 	if err := writeAPIData(opData, tmpl, printer); err != nil {
 		return fmt.Errorf("write output: %w", err)
 	}
 
+	generated := make([]string, 0, len(opData))
+	for _, item := range opData {
+		generated = append(generated, item.OutputFilename)
+	}
+
+	dir := fmt.Sprintf("%s/%s", opts.OutputDirectory, opts.APIName)
+	if err := utils.UpdateManifest(printer, plan, dir, "clients", generated, opts.Prune); err != nil {
+		return fmt.Errorf("update manifest: %w", err)
+	}
+
 	return nil
 }
 
 // getAPIData reads the OpenAPI spec and parses the operation data.
+// It plans the pages of all operations, including the ones that aren't selected,
+// so that their filenames don't depend on the filter.
 func getAPIData(
 	doc *libopenapi.DocumentModel[v3.Document],
 	opts *Options,
+	plan *utils.OutputPlan,
 ) ([]OperationData, error) {
 	var result []OperationData
 
-	count := 0
-
 	beta, err := utils.IsBetaAPI(&doc.Model)
 	if err != nil {
 		return nil, fmt.Errorf("get beta status: %w", err)
@@ -168,31 +317,32 @@ func getAPIData(
 
 	prefix := fmt.Sprintf("%s/%s", opts.OutputDirectory, opts.APIName)
 
-	for pathPairs := doc.Model.Paths.PathItems.First(); pathPairs != nil; pathPairs = pathPairs.Next() {
-		pathName := pathPairs.Key()
-		// Ignore custom HTTP requests
-		if pathName == "/{path}" {
+	ops, err := opts.OperationFilter.PlanOperations(&doc.Model, plan, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, planned := range ops {
+		if !planned.Selected {
 			continue
 		}
 
-		pathItem := pathPairs.Value()
-
-		for opPairs := pathItem.GetOperations().First(); opPairs != nil; opPairs = opPairs.Next() {
-			data, err := buildOperationData(
-				opPairs.Key(),
-				pathName,
-				opPairs.Value(),
-				opts,
-				prefix,
-				beta,
-			)
-			if err != nil {
-				return nil, err
-			}
-
-			result = append(result, data)
-			count++
+		data, err := buildOperationData(
+			planned.Verb,
+			planned.PathName,
+			planned.PathItem,
+			planned.Operation,
+			opts,
+			prefix,
+			beta,
+		)
+		if err != nil {
+			return nil, err
 		}
+
+		data.OutputFilename = planned.Filename
+		data.ReferencePage = strings.TrimSuffix(planned.Filename, filepath.Ext(planned.Filename))
+		result = append(result, data)
 	}
 
 	return result, nil
@@ -200,6 +350,7 @@ func getAPIData(
 
 func buildOperationData(
 	verb, pathName string,
+	pathItem *v3.PathItem,
 	op *v3.Operation,
 	opts *Options,
 	prefix string,
@@ -218,23 +369,27 @@ func buildOperationData(
 	short, long := utils.SplitDescription(op.Description)
 	short = utils.StripMarkdown(short)
 
+	params := utils.Parameters(pathItem, op)
+	body := getRequestBody(op)
+
 	data := OperationData{
 		ACL:              utils.AclToString(acl),
 		APIName:          opts.APIName,
 		Beta:             beta || opBeta,
-		CodeSamples:      getCodeSamples(op),
+		CodeSamples:      getCodeSamples(op, methodArguments(params, body)),
 		Deprecated:       boolOrFalse(op.Deprecated),
 		Description:      long,
-		OutputFilename:   utils.GetOutputFilename(op),
+		LanguageTabs:     opts.LanguageTabs,
 		OutputPath:       prefix,
-		OperationIDKebab: utils.ToKebabCase(op.OperationId),
-		Params:           getParameters(op),
+		Params:           getParameters(params),
 		RequiresAdmin:    false,
-		RequestBody:      getRequestBody(op),
+		RequestBody:      body,
 		ShortDescription: short,
 		Summary:          op.Summary,
 	}
 
+	data.Responses, data.Errors = getResponses(op)
+
 	if data.ACL == "`admin`" {
 		data.RequiresAdmin = true
 	}
@@ -277,13 +432,15 @@ func writeAPIData(
 	return nil
 }
 
-func getCodeSamples(op *v3.Operation) []CodeSample {
+func getCodeSamples(op *v3.Operation, args []string) []CodeSample {
 	node, ok := op.Extensions.Get("x-codeSamples")
 	// Operations can be without code samples
 	if !ok {
 		return nil
 	}
 
+	methodNames := getMethodNames(op)
+
 	var result []CodeSample
 
 	for _, child := range node.Content {
@@ -291,6 +448,12 @@ func getCodeSamples(op *v3.Operation) []CodeSample {
 
 		child.Decode(&c)
 
+		c.Method = methodNames[c.Lang]
+		if c.Method == "" {
+			c.Method = dictionary.MethodName(c.Lang, op.OperationId)
+		}
+
+		c.Signature = methodSignature(c.Lang, c.Method, args)
 		c.Lang = dictionary.NormalizeLang(c.Lang)
 
 		if strings.ToLower(c.Label) != "curl" {
@@ -301,28 +464,143 @@ func getCodeSamples(op *v3.Operation) []CodeSample {
 	return result
 }
 
-func getParameters(op *v3.Operation) []Parameter {
-	var result []Parameter
+// getMethodNames returns the method names from the x-method-name extension by language.
+func getMethodNames(op *v3.Operation) map[string]string {
+	names := map[string]string{}
+
+	if node, ok := op.Extensions.Get("x-method-name"); ok {
+		// Invalid values fall back to the naming conventions
+		_ = node.Decode(&names)
+	}
+
+	return names
+}
+
+// methodArguments returns the names of the required arguments of the API client method:
+// the path parameters, the required query parameters, and the request body.
+func methodArguments(params []*v3.Parameter, body RequestBody) []string {
+	var args []string
 
-	for _, p := range op.Parameters {
-		param := Parameter{
-			Name:        p.Name,
-			Description: p.Description,
+	for _, p := range params {
+		if p.In == "path" || (p.In == "query" && boolOrFalse(p.Required)) {
+			args = append(args, p.Name)
 		}
-		if p.Required != nil {
-			param.Required = *p.Required
+	}
+
+	if body.Name != "" {
+		args = append(args, body.Name)
+	}
+
+	return args
+}
+
+// methodSignature returns the method call with its arguments named after the conventions of the language.
+func methodSignature(lang, method string, args []string) string {
+	names := make([]string, 0, len(args))
+	for _, arg := range args {
+		names = append(names, dictionary.ArgumentName(lang, arg))
+	}
+
+	return fmt.Sprintf("client.%s(%s)", method, strings.Join(names, ", "))
+}
+
+// getParameters returns the parameters grouped by their location.
+func getParameters(params []*v3.Parameter) []ParameterGroup {
+	var result []ParameterGroup
+
+	for _, loc := range parameterLocations {
+		group := ParameterGroup{Location: loc.location, Title: loc.title}
+
+		for _, p := range params {
+			if p.In == loc.location {
+				group.Params = append(group.Params, utils.NewParameterField(p))
+			}
 		}
 
-		result = append(result, param)
+		if len(group.Params) > 0 {
+			result = append(result, group)
+		}
 	}
 
 	return result
 }
 
+// getRequestBody returns the request body of the operation with its resolved schema.
 func getRequestBody(op *v3.Operation) RequestBody {
-	return RequestBody{
-		Description: "Unknown",
+	if op.RequestBody == nil {
+		return RequestBody{}
+	}
+
+	body := RequestBody{
+		Description: strings.TrimSpace(op.RequestBody.Description),
+		Required:    boolOrFalse(op.RequestBody.Required),
+	}
+
+	schema := utils.JSONSchema(op.RequestBody.Content)
+	if schema == nil {
+		return body
+	}
+
+	body.Schema = utils.NewSchemaField("", schema, body.Required)
+	body.Name = body.Schema.Ref
+
+	// Inline schemas are named after the operation, like the generated API clients do
+	if body.Name == "" {
+		body.Name = "body"
+		if op.OperationId != "" {
+			body.Name = op.OperationId + "Request"
+		}
+	}
+
+	// API clients use this name for the request body parameter
+	if node, ok := op.Extensions.Get("x-codegen-request-body-name"); ok && node.Value != "" {
+		body.Name = node.Value
+	}
+
+	return body
+}
+
+// getResponses returns the successful (2xx) responses and the error responses of the operation.
+func getResponses(op *v3.Operation) ([]Response, []Response) {
+	if op.Responses == nil {
+		return nil, nil
+	}
+
+	var success, failures []Response
+
+	if op.Responses.Codes != nil {
+		for pair := op.Responses.Codes.First(); pair != nil; pair = pair.Next() {
+			response := newResponse(pair.Key(), pair.Value())
+
+			if strings.HasPrefix(pair.Key(), "2") {
+				success = append(success, response)
+			} else {
+				failures = append(failures, response)
+			}
+		}
+	}
+
+	if op.Responses.Default != nil {
+		failures = append(failures, newResponse("default", op.Responses.Default))
+	}
+
+	return success, failures
+}
+
+func newResponse(code string, r *v3.Response) Response {
+	response := Response{Code: code}
+
+	if r == nil {
+		return response
 	}
+
+	response.Description = strings.TrimSpace(r.Description)
+
+	if schema := utils.JSONSchema(r.Content); schema != nil {
+		response.Schema = utils.NewSchemaField("", schema, false)
+	}
+
+	return response
 }
 
 func boolOrFalse(val *bool) bool {
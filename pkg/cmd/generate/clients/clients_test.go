@@ -40,7 +40,7 @@ paths:
 		APIName:         "search",
 		InputFilename:   "specs/search.yml",
 		OutputDirectory: "out",
-	})
+	}, utils.NewOutputPlan(""))
 	if err != nil {
 		t.Fatalf("getAPIData() error = %v", err)
 	}
@@ -87,6 +87,505 @@ paths:
 	})
 }
 
+func TestGetAPIDataResolvesRequestBody(t *testing.T) {
+	t.Parallel()
+
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+paths:
+  /1/indexes/{indexName}/synonyms/batch:
+    post:
+      operationId: saveSynonyms
+      summary: Save synonyms
+      x-codegen-request-body-name: synonymHit
+      requestBody:
+        required: true
+        description: Synonyms to save.
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/synonymHit'
+components:
+  schemas:
+    synonymHit:
+      type: object
+      required: [objectID]
+      properties:
+        objectID:
+          type: string
+          description: Unique identifier of a synonym object.
+        type:
+          type: string
+          enum: [synonym, onewaysynonym]
+        synonyms:
+          type: array
+          items:
+            type: string
+`)
+
+	doc, err := utils.LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	data, err := getAPIData(doc, &Options{APIName: "search", OutputDirectory: "out"}, utils.NewOutputPlan(""))
+	if err != nil {
+		t.Fatalf("getAPIData() error = %v", err)
+	}
+
+	body := data[0].RequestBody
+	if body.Name != "synonymHit" || !body.Required || body.Description != "Synonyms to save." {
+		t.Fatalf("RequestBody = %+v, want required synonymHit body with description", body)
+	}
+
+	if got := len(body.Schema.Fields); got != 3 {
+		t.Fatalf("len(RequestBody.Schema.Fields) = %d, want 3", got)
+	}
+
+	tmpl, err := utils.LoadTemplate("", methodTemplateName, methodTemplate)
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data[0]); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertRenderedContains(t, rendered.String(), []string{
+		"## Request body\n\nSynonyms to save.",
+		"<ParamField body=\"objectID\" type=\"string\" required>\nUnique identifier of a synonym object.\n</ParamField>",
+		"<ParamField body=\"type\" type=\"string\">\n\nPossible values: `synonym`, `onewaysynonym`",
+		"<ParamField body=\"synonyms\" type=\"array<string>\">",
+	})
+}
+
+func TestGetAPIDataNamesInlineRequestBody(t *testing.T) {
+	t.Parallel()
+
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+paths:
+  /1/keys:
+    post:
+      operationId: addApiKey
+      summary: Add API key
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                acl:
+                  type: array
+                  items:
+                    type: string
+      x-codeSamples:
+        - lang: python
+          source: 'client.add_api_key(api_key={"acl": ["search"]})'
+`)
+
+	doc, err := utils.LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	data, err := getAPIData(doc, &Options{APIName: "search", OutputDirectory: "out"}, utils.NewOutputPlan(""))
+	if err != nil {
+		t.Fatalf("getAPIData() error = %v", err)
+	}
+
+	if got := data[0].RequestBody.Name; got != "addApiKeyRequest" {
+		t.Errorf("RequestBody.Name = %q, want addApiKeyRequest", got)
+	}
+
+	if got := data[0].CodeSamples[0].Signature; got != "client.add_api_key(add_api_key_request)" {
+		t.Errorf("Signature = %q, want client.add_api_key(add_api_key_request)", got)
+	}
+}
+
+func TestGetAPIDataLinksPlannedReferencePages(t *testing.T) {
+	t.Parallel()
+
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+paths:
+  /1/indexes/{indexName}/{objectID}:
+    get:
+      operationId: getObject
+      summary: Retrieve a record
+  /1/objects:
+    post:
+      operationId: get_object
+      summary: Retrieve records
+`)
+
+	doc, err := utils.LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	data, err := getAPIData(
+		doc,
+		&Options{APIName: "search", OutputDirectory: "out"},
+		utils.NewOutputPlan(utils.OnCollisionSuffix),
+	)
+	if err != nil {
+		t.Fatalf("getAPIData() error = %v", err)
+	}
+
+	tmpl, err := utils.LoadTemplate("", methodTemplateName, methodTemplate)
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+
+	for i, want := range []string{"get-object", "get-object-2"} {
+		if data[i].ReferencePage != want {
+			t.Errorf("ReferencePage = %q, want %q", data[i].ReferencePage, want)
+		}
+
+		var rendered bytes.Buffer
+		if err := tmpl.Execute(&rendered, data[i]); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+
+		assertRenderedContains(t, rendered.String(), []string{`href="/doc/rest-api/search/` + want + `"`})
+	}
+}
+
+func TestGetAPIDataGroupsParameters(t *testing.T) {
+	t.Parallel()
+
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+paths:
+  /1/indexes/{indexName}/browse:
+    get:
+      operationId: browse
+      summary: Browse for records
+      parameters:
+        - name: hitsPerPage
+          in: query
+          deprecated: true
+          schema:
+            type: integer
+            format: int32
+            minimum: 1
+            maximum: 1000
+            default: 20
+        - name: X-Algolia-User-ID
+          in: header
+          description: User ID.
+          schema:
+            type: string
+          example: user1234
+        - name: indexName
+          in: path
+          required: true
+          schema:
+            type: string
+`)
+
+	doc, err := utils.LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	data, err := getAPIData(doc, &Options{APIName: "search", OutputDirectory: "out"}, utils.NewOutputPlan(""))
+	if err != nil {
+		t.Fatalf("getAPIData() error = %v", err)
+	}
+
+	groups := data[0].Params
+
+	var locations []string
+	for _, group := range groups {
+		locations = append(locations, group.Location)
+	}
+
+	if got := strings.Join(locations, ","); got != "path,query,header" {
+		t.Fatalf("parameter locations = %s, want path,query,header", got)
+	}
+
+	tmpl, err := utils.LoadTemplate("", methodTemplateName, methodTemplate)
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data[0]); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertRenderedContains(t, rendered.String(), []string{
+		"## Path parameters\n\n<ParamField path=\"indexName\" type=\"string\" required>",
+		"<ParamField query=\"hitsPerPage\" type=\"integer\" default=\"20\" deprecated>",
+		"Format: `int32`\n\nMinimum: `1`\n\nMaximum: `1000`",
+		"<ParamField header=\"X-Algolia-User-ID\" type=\"string\">\nUser ID.\n\nExamples: `user1234`",
+	})
+}
+
+func TestGetAPIDataMergesPathItemParameters(t *testing.T) {
+	t.Parallel()
+
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+paths:
+  /1/indexes/{indexName}/query:
+    parameters:
+      - name: indexName
+        in: path
+        required: true
+        schema:
+          type: string
+      - name: hitsPerPage
+        in: query
+        description: Path item description.
+        schema:
+          type: integer
+    post:
+      operationId: searchSingleIndex
+      summary: Search an index
+      parameters:
+        - name: hitsPerPage
+          in: query
+          description: Operation description.
+          schema:
+            type: integer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/searchParams'
+      x-codeSamples:
+        - lang: python
+          source: 'client.search_single_index(index_name="ALGOLIA_INDEX_NAME")'
+components:
+  schemas:
+    searchParams:
+      type: object
+      properties:
+        query:
+          type: string
+`)
+
+	doc, err := utils.LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	data, err := getAPIData(doc, &Options{APIName: "search", OutputDirectory: "out"}, utils.NewOutputPlan(""))
+	if err != nil {
+		t.Fatalf("getAPIData() error = %v", err)
+	}
+
+	tmpl, err := utils.LoadTemplate("", methodTemplateName, methodTemplate)
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data[0]); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertRenderedContains(t, rendered.String(), []string{
+		"## Path parameters\n\n<ParamField path=\"indexName\" type=\"string\" required>",
+		"<ParamField query=\"hitsPerPage\" type=\"integer\">\nOperation description.",
+	})
+
+	if strings.Contains(rendered.String(), "Path item description.") {
+		t.Errorf("rendered page has the overridden path item parameter:\n%s", rendered.String())
+	}
+
+	want := "client.search_single_index(index_name, search_params)"
+	if got := data[0].CodeSamples[0].Signature; got != want {
+		t.Errorf("Signature = %q, want %q", got, want)
+	}
+}
+
+func TestGetAPIDataResolvesResponses(t *testing.T) {
+	t.Parallel()
+
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+paths:
+  /1/indexes/{indexName}/{objectID}:
+    delete:
+      operationId: deleteObject
+      summary: Delete a record
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/deletedAtResponse'
+        '404':
+          description: Index not found.
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/ErrorBase'
+components:
+  schemas:
+    deletedAtResponse:
+      allOf:
+        - $ref: '#/components/schemas/taskIDResponse'
+        - type: object
+          required: [deletedAt]
+          properties:
+            deletedAt:
+              type: string
+              description: Date and time when the record was deleted.
+    taskIDResponse:
+      type: object
+      required: [taskID]
+      properties:
+        taskID:
+          type: integer
+          format: int64
+    ErrorBase:
+      type: object
+      properties:
+        message:
+          type: string
+`)
+
+	doc, err := utils.LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	data, err := getAPIData(doc, &Options{APIName: "search", OutputDirectory: "out"}, utils.NewOutputPlan(""))
+	if err != nil {
+		t.Fatalf("getAPIData() error = %v", err)
+	}
+
+	if got := len(data[0].Responses); got != 1 {
+		t.Fatalf("len(Responses) = %d, want 1", got)
+	}
+
+	if got := len(data[0].Errors); got != 1 || data[0].Errors[0].Code != "404" {
+		t.Fatalf("Errors = %+v, want one 404 response", data[0].Errors)
+	}
+
+	tmpl, err := utils.LoadTemplate("", methodTemplateName, methodTemplate)
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data[0]); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertRenderedContains(t, rendered.String(), []string{
+		"## Response\n\n<ResponseField name=\"taskID\" type=\"integer\" required>\n\nFormat: `int64`",
+		"<ResponseField name=\"deletedAt\" type=\"string\" required>\nDate and time when the record was deleted.",
+		"## Errors\n\n- `404`: Index not found.",
+	})
+}
+
+func TestGetAPIDataLanguageTabs(t *testing.T) {
+	t.Parallel()
+
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+paths:
+  /1/indexes/{indexName}/query:
+    post:
+      operationId: searchSingleIndex
+      summary: Search an index
+      x-codegen-request-body-name: searchParams
+      x-method-name:
+        javascript: searchIndex
+      parameters:
+        - name: indexName
+          in: path
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                query:
+                  type: string
+      x-codeSamples:
+        - lang: python
+          label: Python
+          source: client.search_single_index(index_name="ALGOLIA_INDEX_NAME")
+        - lang: go
+          label: Go
+          source: client.SearchSingleIndex(client.NewApiSearchSingleIndexRequest("ALGOLIA_INDEX_NAME"))
+        - lang: javascript
+          label: JavaScript
+          source: "client.searchIndex({ indexName: 'ALGOLIA_INDEX_NAME' });"
+`)
+
+	doc, err := utils.LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	data, err := getAPIData(
+		doc,
+		&Options{APIName: "search", OutputDirectory: "out", LanguageTabs: true},
+		utils.NewOutputPlan(""),
+	)
+	if err != nil {
+		t.Fatalf("getAPIData() error = %v", err)
+	}
+
+	var methods []string
+	for _, sample := range data[0].CodeSamples {
+		methods = append(methods, sample.Method)
+	}
+
+	if got := strings.Join(methods, ","); got != "search_single_index,SearchSingleIndex,searchIndex" {
+		t.Fatalf("methods = %s, want search_single_index,SearchSingleIndex,searchIndex", got)
+	}
+
+	tmpl, err := utils.LoadTemplate("", methodTemplateName, methodTemplate)
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data[0]); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertRenderedContains(t, rendered.String(), []string{
+		"<Tabs>",
+		"<Tab title=\"Python\">\n`client.search_single_index(index_name, search_params)`\n\n```python Python",
+		"<Tab title=\"Go\">\n`client.SearchSingleIndex(indexName, searchParams)`",
+		"<Tab title=\"JavaScript\">\n`client.searchIndex(indexName, searchParams)`\n\n```js JavaScript\n" +
+			"client.searchIndex({ indexName: 'ALGOLIA_INDEX_NAME' });\n```\n</Tab>\n\n</Tabs>",
+	})
+
+	if strings.Contains(rendered.String(), "<CodeGroup>") {
+		t.Fatalf("rendered method has <CodeGroup> in language tabs mode:\n%s", rendered.String())
+	}
+}
+
 func parseFrontmatter(t *testing.T, rendered string) map[string]any {
 	t.Helper()
 
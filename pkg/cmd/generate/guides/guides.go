@@ -18,8 +18,9 @@ import (
 )
 
 type Options struct {
-	GuidesFile      string
-	OutputDirectory string
+	GuidesFile      string `yaml:"input"`
+	OutputDirectory string `yaml:"output"`
+	OnCollision     string `yaml:"on-collision"`
 }
 
 // GuidesMap represents the data from a guide file.
@@ -34,6 +35,10 @@ func NewGuidesCommand() *cobra.Command {
 		Long: heredoc.Doc(`
 			This command reads a data file with guide snippets.
 			It generates an MDX file for each guide.
+
+			Guide names that only differ in case or separators have the same output file.
+			The command lists these guides and stops before writing files.
+			Use --on-collision suffix to write the later guides to numbered files instead.
 		`),
 		Example: heredoc.Doc(`
 			# Run from root of algolia/docs-new
@@ -41,6 +46,8 @@ func NewGuidesCommand() *cobra.Command {
 		`),
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
 			opts.GuidesFile = args[0]
 
 			printer, err := output.New(cmd)
@@ -48,17 +55,20 @@ func NewGuidesCommand() *cobra.Command {
 				return err
 			}
 
-			return runCommand(opts, printer)
+			return printer.Finish(Run(opts, printer))
 		},
 	}
 
 	cmd.Flags().
 		StringVarP(&opts.OutputDirectory, "output", "o", "out", "Output directory for generated MDX files")
+	cmd.Flags().
+		StringVar(&opts.OnCollision, "on-collision", utils.OnCollisionFail, "Handle colliding output files: fail or suffix")
 
 	return cmd
 }
 
-func runCommand(opts *Options, printer *output.Printer) error {
+// Run runs the `generate guides` command with the given options.
+func Run(opts *Options, printer *output.Printer) error {
 	if err := validate.ExistingFile(opts.GuidesFile, "guides file"); err != nil {
 		return err
 	}
@@ -67,11 +77,17 @@ func runCommand(opts *Options, printer *output.Printer) error {
 		return err
 	}
 
+	if err := utils.ValidateOnCollision(opts.OnCollision); err != nil {
+		return err
+	}
+
 	bytes, err := os.ReadFile(opts.GuidesFile)
 	if err != nil {
 		return fmt.Errorf("read guides file %s: %w", opts.GuidesFile, err)
 	}
 
+	printer.AddInput(opts.GuidesFile)
+
 	var data GuidesMap
 	if err := json.Unmarshal(bytes, &data); err != nil {
 		return fmt.Errorf("parse guides file %s: %w", opts.GuidesFile, err)
@@ -87,10 +103,21 @@ func runCommand(opts *Options, printer *output.Printer) error {
 
 	sort.Strings(guideNames)
 
-	for _, guide := range guideNames {
+	plan := utils.NewOutputPlan(opts.OnCollision)
+
+	filenames := make([]string, len(guideNames))
+	for i, guide := range guideNames {
+		filenames[i] = plan.Add(opts.OutputDirectory, fmt.Sprintf("%s.mdx", utils.ToKebabCase(guide)), "guide "+guide)
+	}
+
+	if err := plan.Check(printer); err != nil {
+		return err
+	}
+
+	for i, guide := range guideNames {
 		err := writeGuide(
 			opts.OutputDirectory,
-			fmt.Sprintf("%s.mdx", utils.ToKebabCase(guide)),
+			filenames[i],
 			generateMarkdownSnippet(data[guide]),
 			printer,
 		)
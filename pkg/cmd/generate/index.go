@@ -2,10 +2,13 @@ package generate
 
 import (
 	"github.com/MakeNowJust/heredoc"
+	"github.com/algolia/docli/pkg/cmd/generate/all"
 	"github.com/algolia/docli/pkg/cmd/generate/cdn"
+	"github.com/algolia/docli/pkg/cmd/generate/changelog"
 	"github.com/algolia/docli/pkg/cmd/generate/clients"
 	"github.com/algolia/docli/pkg/cmd/generate/guides"
 	"github.com/algolia/docli/pkg/cmd/generate/openapi"
+	"github.com/algolia/docli/pkg/cmd/generate/schemas"
 	"github.com/algolia/docli/pkg/cmd/generate/sla"
 	"github.com/algolia/docli/pkg/cmd/generate/snippets"
 	"github.com/spf13/cobra"
@@ -29,12 +32,15 @@ func NewGenerateCmd() *cobra.Command {
 		`),
 	}
 
+	command.AddCommand(all.NewAllCommand())
 	command.AddCommand(clients.NewClientsCommand())
 	command.AddCommand(openapi.NewOpenAPICommand())
+	command.AddCommand(schemas.NewSchemasCommand())
 	command.AddCommand(sla.NewSLACommand())
 	command.AddCommand(snippets.NewSnippetsCommand())
 	command.AddCommand(guides.NewGuidesCommand())
 	command.AddCommand(cdn.NewCdnCommand())
+	command.AddCommand(changelog.NewChangelogCommand())
 
 	return command
 }
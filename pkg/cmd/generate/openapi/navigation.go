@@ -0,0 +1,136 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/algolia/docli/pkg/navigation"
+	"github.com/algolia/docli/pkg/output"
+	"github.com/algolia/docli/pkg/validate"
+	"github.com/pb33f/libopenapi"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+func validateNavigationOptions(opts *Options, dryRun bool) error {
+	// The navigation group lists all pages, so it needs all operations
+	if (opts.Navigation != "" || opts.DocsJSON != "") && opts.OperationFilter.IsPartial() {
+		return errors.New("can't write the navigation when filtering operations by tag or operation ID")
+	}
+
+	if opts.Navigation != "" {
+		if dryRun {
+			if err := validate.OutputFileDryRun(opts.Navigation, "navigation file"); err != nil {
+				return err
+			}
+		} else if err := validate.OutputFile(opts.Navigation, "navigation file"); err != nil {
+			return err
+		}
+	}
+
+	if opts.DocsJSON != "" {
+		if err := validate.ExistingFile(opts.DocsJSON, "docs.json file"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeNavigation writes the navigation group to a JSON file or merges it into docs.json.
+func writeNavigation(
+	doc *libopenapi.DocumentModel[v3.Document],
+	overview OverviewData,
+	data []OperationData,
+	opts *Options,
+	printer *output.Printer,
+) error {
+	if opts.Navigation == "" && opts.DocsJSON == "" {
+		return nil
+	}
+
+	name := opts.NavigationGroup
+	if name == "" {
+		name = overview.Title
+	}
+
+	group := buildNavigation(name, overview, data, specTags(doc))
+
+	if opts.Navigation != "" {
+		err := printer.WriteFile(opts.Navigation, func(w io.Writer) error {
+			return navigation.Encode(w, group)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.DocsJSON == "" {
+		return nil
+	}
+
+	docs, err := os.ReadFile(opts.DocsJSON)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", opts.DocsJSON, err)
+	}
+
+	printer.AddInput(opts.DocsJSON)
+
+	merged, err := navigation.Merge(docs, group)
+	if err != nil {
+		return fmt.Errorf("merge into %s: %w", opts.DocsJSON, err)
+	}
+
+	return printer.WriteFile(opts.DocsJSON, func(w io.Writer) error {
+		_, err := w.Write(merged)
+
+		return err
+	})
+}
+
+// buildNavigation returns the navigation group with the overview page first,
+// followed by the operation pages grouped by tag.
+// Operations without tags come right after the overview.
+func buildNavigation(
+	name string,
+	overview OverviewData,
+	data []OperationData,
+	tags []string,
+) *navigation.Group {
+	result := &navigation.Group{
+		Group: name,
+		Pages: []any{navigation.PagePath(path.Join(overview.OutputPath, overview.OutputFilename))},
+	}
+
+	for _, group := range groupByTag(data, tags) {
+		pages := make([]any, 0, len(group.Operations))
+		for _, item := range group.Operations {
+			pages = append(pages, navigation.PagePath(path.Join(item.OutputPath, item.OutputFilename)))
+		}
+
+		if group.Tag == "" {
+			result.Pages = append(result.Pages, pages...)
+
+			continue
+		}
+
+		result.Pages = append(result.Pages, &navigation.Group{Group: group.Tag, Pages: pages})
+	}
+
+	return result
+}
+
+// specTags returns the names of the tags declared in the spec.
+func specTags(doc *libopenapi.DocumentModel[v3.Document]) []string {
+	var tags []string
+
+	for _, tag := range doc.Model.Tags {
+		if tag != nil {
+			tags = append(tags, tag.Name)
+		}
+	}
+
+	return tags
+}
@@ -0,0 +1,45 @@
+package openapi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/algolia/docli/pkg/navigation"
+)
+
+func TestBuildNavigation(t *testing.T) {
+	overview := OverviewData{OutputPath: "doc/rest-api", OutputFilename: "search.mdx"}
+	data := []OperationData{
+		{OutputPath: "doc/rest-api/search", OutputFilename: "save-object.mdx", Tag: "Records"},
+		{OutputPath: "doc/rest-api/search", OutputFilename: "search-single-index.mdx", Tag: "Search"},
+		{OutputPath: "doc/rest-api/search", OutputFilename: "custom-get.mdx"},
+		{OutputPath: "doc/rest-api/search", OutputFilename: "get-object.mdx", Tag: "Records"},
+		{OutputPath: "doc/rest-api/search", OutputFilename: "list-clusters.mdx", Tag: "Clusters"},
+	}
+
+	got := buildNavigation("Search API", overview, data, []string{"Search", "Indices", "Records"})
+
+	want := &navigation.Group{
+		Group: "Search API",
+		Pages: []any{
+			"doc/rest-api/search",
+			"doc/rest-api/search/custom-get",
+			&navigation.Group{
+				Group: "Search",
+				Pages: []any{"doc/rest-api/search/search-single-index"},
+			},
+			&navigation.Group{
+				Group: "Records",
+				Pages: []any{"doc/rest-api/search/save-object", "doc/rest-api/search/get-object"},
+			},
+			&navigation.Group{
+				Group: "Clusters",
+				Pages: []any{"doc/rest-api/search/list-clusters"},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildNavigation() =\n%#v\nwant\n%#v", got, want)
+	}
+}
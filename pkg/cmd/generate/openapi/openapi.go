@@ -5,12 +5,15 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 	"text/template"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/algolia/docli/pkg/cmd/generate/utils"
+	"github.com/algolia/docli/pkg/navigation"
 	"github.com/algolia/docli/pkg/output"
 	"github.com/algolia/docli/pkg/validate"
 	"github.com/pb33f/libopenapi"
@@ -20,9 +23,17 @@ import (
 
 // Options represents the options and flags for this command.
 type Options struct {
-	APIName         string
-	InputFileName   string
-	OutputDirectory string
+	APIName         string `yaml:"api-name"`
+	InputFileName   string `yaml:"input"`
+	OutputDirectory string `yaml:"output"`
+	Prune           bool   `yaml:"prune"`
+	TemplatesDir    string `yaml:"templates-dir"`
+	Navigation      string `yaml:"navigation"`
+	DocsJSON        string `yaml:"docs-json"`
+	NavigationGroup string `yaml:"navigation-group"`
+	OnCollision     string `yaml:"on-collision"`
+	// The filter options select the operations to generate.
+	utils.OperationFilter `yaml:",inline"`
 }
 
 // ExternalDocs holds an externalDocs reference.
@@ -33,9 +44,14 @@ type ExternalDocs struct {
 
 // OverviewData holds relevant data for the entire spec.
 type OverviewData struct {
+	AuthSchemes      []AuthScheme
 	Description      string
+	Groups           []OperationGroup
+	Limits           []Limit
 	OutputFilename   string
 	OutputPath       string
+	Servers          []Server
+	ServerVariables  []ServerVariable
 	ShortDescription string
 	Title            string
 }
@@ -45,24 +61,66 @@ type OperationData struct {
 	ACL              string
 	APIPath          string
 	Beta             bool
+	Callbacks        []Callback
+	Deprecated       bool
+	DeprecatedBy     *Replacement
 	Description      string
 	ExternalDocs     ExternalDocs
 	InputFilename    string
+	OperationID      string
 	OutputFilename   string
 	OutputPath       string
 	RequiresAdmin    bool
 	SeeAlso          bool
 	ShortDescription string
+	Tag              string
 	Title            string
 	Verb             string
+	Webhook          string
 }
 
+// Replacement is the operation that replaces a deprecated operation.
+type Replacement struct {
+	OperationID string
+	Title       string
+	// Link is empty if the replacement doesn't have a page.
+	Link string
+}
+
+// OperationGroup holds the operations with the same tag.
+type OperationGroup struct {
+	// Tag is empty for operations without tags.
+	Tag        string
+	Operations []OperationData
+}
+
+// Callback describes a request that the API sends in response to an operation.
+type Callback struct {
+	Name       string
+	Expression string
+	Verb       string
+	Summary    string
+}
+
+const (
+	overviewTemplateName = "overview.mdx.tmpl"
+	stubTemplateName     = "stub.mdx.tmpl"
+)
+
 //go:embed overview.mdx.tmpl
 var overviewTemplate string
 
 //go:embed stub.mdx.tmpl
 var stubTemplate string
 
+// DefaultTemplates returns the built-in templates of this command by file name.
+func DefaultTemplates() map[string]string {
+	return map[string]string{
+		overviewTemplateName: overviewTemplate,
+		stubTemplateName:     stubTemplate,
+	}
+}
+
 // NewOpenAPICommand returns a new instance of the `generate openapi` command.
 func NewOpenAPICommand() *cobra.Command {
 	opts := &Options{}
@@ -74,35 +132,116 @@ func NewOpenAPICommand() *cobra.Command {
 		Long: heredoc.Doc(`
 			This command reads an OpenAPI 3 spec and generates one MDX file per API operation.
 			Useful when adding new operations or changing operation summaries.
-			It doesn't delete MDX files. If you remove or rename an operation,
-			you need to update or delete its MDX file manually.
+			Webhooks get their own MDX files too,
+			and callbacks are listed on the page of the operation that triggers them.
+			The overview page of the API lists the base URLs, the authentication headers,
+			rate limits and timeouts from x-rate-limit or x-timeouts style extensions,
+			and all operations grouped by tag, with links to their pages.
+
+			Deprecated operations have a warning on their page and are flagged on the overview page.
+			If the operation has an x-deprecated-by extension with the ID of another operation,
+			the warning links to the page of that operation.
+
+			To add hand-written content to a generated page, put it between
+			{/* docli:keep-start */} and {/* docli:keep-end */} comments.
+			The command keeps these sections after the same generated line when it updates the page.
+			If that line no longer exists, the section moves to the end of the page with a warning.
+
+			The command records the generated files in a manifest file (.docli-manifest.openapi.json).
+			With --prune, it deletes generated MDX files for operations that no longer exist,
+			for example, after removing or renaming an operation.
+			Files that aren't listed in the manifest are never deleted.
+
+			To generate only some operations, use --include-tag, --exclude-tag,
+			or --operation with glob patterns for operation IDs, such as 'get*'.
+			Pages of the other operations stay in the manifest and aren't pruned,
+			and the overview page isn't updated.
+			To keep internal operations out of the docs, use --exclude-extension,
+			for example, --exclude-extension x-internal.
+			Operations with an excluded extension are pruned.
+
+			Operations at the path /{path}, which is for custom HTTP requests, don't get a page.
+			Use --skip-path to change the skipped paths.
+			To skip other operations, add an x-docli-skip: true extension to the operation or its path.
+
+			Operation IDs that only differ in case or separators, such as getObject and get_object,
+			have the same output file. The command lists these operations and stops before writing files.
+			Use --on-collision suffix to write the later pages to numbered files instead,
+			such as get-object-2.mdx.
+
+			The pages go into a directory named after the API.
+			The API name is the name of the spec file, unless the spec has an x-docli-api-name extension.
+			Use --api-name to set the API name.
+
+			To change the generated pages, use --templates-dir with a directory
+			that has your own overview.mdx.tmpl or stub.mdx.tmpl template.
+			Missing templates fall back to the built-in ones.
+			Run 'docli templates export' to start from the built-in templates.
+
+			With --navigation, the command also writes a navigation group for the docs.json file.
+			The group has the overview page first, followed by the operation pages
+			grouped by their first tag, in the order of the tags in the spec.
+			With --docs-json, it replaces the pages of the group with the same name in docs.json.
+			The group name defaults to the title of the spec, use --navigation-group to change it.
+			The group must already be in docs.json, with a pages list.
+			Only the pages change, the rest of docs.json keeps its formatting.
 		`),
 		Example: heredoc.Doc(`
   		# Run from root of algolia/docs-new
 			docli gen stubs specs/search.yml -o doc/rest-api
+
+			# Update the pages in the "Search API" navigation group
+			docli gen stubs specs/search.yml -o doc/rest-api --docs-json docs.json --navigation-group "Search API"
     `),
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
 			opts.InputFileName = args[0]
-			opts.APIName = utils.GetAPIName(opts.InputFileName)
 
 			printer, err := output.New(cmd)
 			if err != nil {
 				return err
 			}
 
-			return runCommand(opts, printer)
+			return printer.Finish(Run(opts, printer))
 		},
 	}
 
 	cmd.Flags().
 		StringVarP(&opts.OutputDirectory, "output", "o", "out", "Output directory for generated MDX files")
+	cmd.Flags().
+		BoolVar(&opts.Prune, "prune", false, "Delete generated MDX files for operations that no longer exist")
+	cmd.Flags().
+		StringVar(&opts.TemplatesDir, "templates-dir", "", "Directory with templates that replace the built-in ones")
+	cmd.Flags().
+		StringVar(&opts.Navigation, "navigation", "", "JSON file for the navigation group with the generated pages")
+	cmd.Flags().
+		StringVar(&opts.DocsJSON, "docs-json", "", "Mintlify docs.json file to update with the navigation group")
+	cmd.Flags().
+		StringVar(&opts.NavigationGroup, "navigation-group", "", "Name of the navigation group (default: title of the spec)")
+	cmd.Flags().
+		StringSliceVar(&opts.IncludeTags, "include-tag", nil, "Only generate operations with one of these tags")
+	cmd.Flags().
+		StringSliceVar(&opts.ExcludeTags, "exclude-tag", nil, "Don't generate operations with one of these tags")
+	cmd.Flags().
+		StringSliceVar(&opts.Operations, "operation", nil, "Only generate operations with IDs matching these glob patterns")
+	cmd.Flags().
+		StringSliceVar(&opts.ExcludeExtensions, "exclude-extension", nil, "Don't generate operations with these extensions")
+	cmd.Flags().
+		StringSliceVar(&opts.SkipPaths, "skip-path", utils.DefaultSkipPaths, "Don't generate operations for these paths")
+	cmd.Flags().
+		StringVar(&opts.APIName, "api-name", "", "Name of the API for the output directory (default: from the spec)")
+	cmd.Flags().
+		StringVar(&opts.OnCollision, "on-collision", utils.OnCollisionFail, "Handle colliding output files: fail or suffix")
 
 	return cmd
 }
 
-// runCommand runs the `generate openapi` command.
-func runCommand(opts *Options, printer *output.Printer) error {
+// Run runs the `generate openapi` command with the given options.
+// The API name defaults to the x-docli-api-name extension of the spec,
+// or to the name of the spec file.
+func Run(opts *Options, printer *output.Printer) error {
 	if err := validate.ExistingFile(opts.InputFileName, "spec file"); err != nil {
 		return err
 	}
@@ -111,11 +250,31 @@ func runCommand(opts *Options, printer *output.Printer) error {
 		return err
 	}
 
+	if opts.TemplatesDir != "" {
+		if err := validate.ExistingDir(opts.TemplatesDir, "templates directory"); err != nil {
+			return err
+		}
+	}
+
+	if err := validateNavigationOptions(opts, printer.IsDryRun()); err != nil {
+		return err
+	}
+
+	if err := opts.OperationFilter.Validate(); err != nil {
+		return err
+	}
+
+	if err := utils.ValidateOnCollision(opts.OnCollision); err != nil {
+		return err
+	}
+
 	specFile, err := os.ReadFile(opts.InputFileName)
 	if err != nil {
 		return fmt.Errorf("read spec file %s: %w", opts.InputFileName, err)
 	}
 
+	printer.AddInput(opts.InputFileName)
+
 	printer.Infof("Generating MDX stub files for spec: %s\n", opts.InputFileName)
 	printer.Infof("Writing output in: %s\n", opts.OutputDirectory)
 
@@ -124,49 +283,85 @@ func runCommand(opts *Options, printer *output.Printer) error {
 		return fmt.Errorf("load spec %s: %w", opts.InputFileName, err)
 	}
 
-	overviewData, err := getAPIOverviewData(spec, opts)
+	opts.APIName, err = utils.ResolveAPIName(&spec.Model, opts.APIName, opts.InputFileName)
 	if err != nil {
-		return fmt.Errorf("build overview data for %s: %w", opts.InputFileName, err)
+		return err
 	}
 
-	ovTmpl := template.Must(template.New("overview").Funcs(template.FuncMap{
-		"frontmatterString": utils.QuoteFrontmatterString,
-	}).Parse(overviewTemplate))
+	plan := utils.NewOutputPlan(opts.OnCollision)
 
-	err = writeOverviewData(overviewData, ovTmpl, printer)
+	opData, err := getAPIData(spec, opts, plan)
 	if err != nil {
-		return fmt.Errorf("write overview: %w", err)
+		return fmt.Errorf("build operation data for %s: %w", opts.InputFileName, err)
 	}
 
-	opData, err := getAPIData(spec, opts)
-	if err != nil {
-		return fmt.Errorf("build operation data for %s: %w", opts.InputFileName, err)
+	if err := plan.Check(printer); err != nil {
+		return err
 	}
 
 	printer.Verbosef("Spec %s has %d operations.\n", opts.InputFileName, len(opData))
 
-	tmpl := template.Must(template.New("stub").Funcs(template.FuncMap{
-		"frontmatterString": utils.QuoteFrontmatterString,
-	}).Parse(stubTemplate))
+	overviewData, err := getAPIOverviewData(spec, opData, opts)
+	if err != nil {
+		return fmt.Errorf("build overview data for %s: %w", opts.InputFileName, err)
+	}
+
+	ovTmpl, err := utils.LoadTemplate(opts.TemplatesDir, overviewTemplateName, overviewTemplate)
+	if err != nil {
+		return err
+	}
+
+	// The overview lists all operations, so don't overwrite it with a part of them
+	if opts.OperationFilter.IsPartial() {
+		printer.Verbosef("Not updating the overview page because operations are filtered.\n")
+	} else if err := writeOverviewData(overviewData, ovTmpl, printer); err != nil {
+		return fmt.Errorf("write overview: %w", err)
+	}
+
+	tmpl, err := utils.LoadTemplate(opts.TemplatesDir, stubTemplateName, stubTemplate)
+	if err != nil {
+		return err
+	}
 
 	err = writeAPIData(opData, tmpl, printer)
 	if err != nil {
 		return fmt.Errorf("write operations: %w", err)
 	}
 
+	generated := make([]string, 0, len(opData))
+	for _, item := range opData {
+		generated = append(generated, item.OutputFilename)
+	}
+
+	dir := fmt.Sprintf("%s/%s", opts.OutputDirectory, opts.APIName)
+	if err := utils.UpdateManifest(printer, plan, dir, "openapi", generated, opts.Prune); err != nil {
+		return fmt.Errorf("update manifest: %w", err)
+	}
+
+	if err := writeNavigation(spec, overviewData, opData, opts, printer); err != nil {
+		return fmt.Errorf("update navigation: %w", err)
+	}
+
 	return nil
 }
 
 // getAPIOverviewData generates MDX stub data for the API spec.
+// The overview explains how to connect to the API and lists the operations grouped by tag.
 func getAPIOverviewData(
 	doc *libopenapi.DocumentModel[v3.Document],
+	data []OperationData,
 	opts *Options,
 ) (OverviewData, error) {
 	result := OverviewData{
-		OutputFilename: fmt.Sprintf("%s.mdx", opts.APIName),
-		OutputPath:     opts.OutputDirectory,
-		Title:          doc.Model.Info.Title,
-		Description:    strings.TrimSpace(doc.Model.Info.Description),
+		AuthSchemes:     getAuthSchemes(&doc.Model),
+		Groups:          groupByTag(data, specTags(doc)),
+		Limits:          getLimits(&doc.Model),
+		OutputFilename:  fmt.Sprintf("%s.mdx", opts.APIName),
+		OutputPath:      opts.OutputDirectory,
+		Servers:         getServers(&doc.Model),
+		ServerVariables: getServerVariables(&doc.Model),
+		Title:           doc.Model.Info.Title,
+		Description:     strings.TrimSpace(doc.Model.Info.Description),
 	}
 
 	short := strings.TrimSpace(doc.Model.Info.Summary)
@@ -180,14 +375,15 @@ func getAPIOverviewData(
 }
 
 // getAPIData generates the MDX stub data for each OpenAPI operation in the spec.
+// It plans the pages of all operations, including the ones that aren't selected,
+// so that their filenames don't depend on the filter.
 func getAPIData(
 	doc *libopenapi.DocumentModel[v3.Document],
 	opts *Options,
+	plan *utils.OutputPlan,
 ) ([]OperationData, error) {
 	var result []OperationData
 
-	count := 0
-
 	beta, err := utils.IsBetaAPI(&doc.Model)
 	if err != nil {
 		return nil, fmt.Errorf("get beta status: %w", err)
@@ -195,36 +391,159 @@ func getAPIData(
 
 	prefix := fmt.Sprintf("%s/%s", opts.OutputDirectory, opts.APIName)
 
-	for pathPairs := doc.Model.Paths.PathItems.First(); pathPairs != nil; pathPairs = pathPairs.Next() {
-		pathName := pathPairs.Key()
-		// Ignore custom HTTP requests
-		if pathName == "/{path}" {
+	ops, err := opts.OperationFilter.PlanOperations(&doc.Model, plan, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pages of all operations, including the ones that aren't selected, for linking replacements
+	pages := map[string]Replacement{}
+
+	for _, planned := range ops {
+		op := planned.Operation
+
+		pages[op.OperationId] = Replacement{
+			OperationID: op.OperationId,
+			Title:       strings.TrimSpace(op.Summary),
+			Link:        "/" + navigation.PagePath(path.Join(prefix, planned.Filename)),
+		}
+
+		if !planned.Selected {
+			continue
+		}
+
+		data, err := buildOperationData(planned.Verb, planned.PathName, op, opts, prefix, beta)
+		if err != nil {
+			return nil, err
+		}
+
+		data.OutputFilename = planned.Filename
+		result = append(result, data)
+	}
+
+	webhooks, err := getWebhookData(doc, opts, plan, prefix, beta)
+	if err != nil {
+		return nil, err
+	}
+
+	result = append(result, webhooks...)
+	linkReplacements(result, pages)
+
+	return result, nil
+}
+
+// linkReplacements links deprecated operations to the pages of their replacements.
+func linkReplacements(data []OperationData, pages map[string]Replacement) {
+	for i := range data {
+		replacement := data[i].DeprecatedBy
+		if replacement == nil {
 			continue
 		}
 
-		pathItem := pathPairs.Value()
-
-		for opPairs := pathItem.GetOperations().First(); opPairs != nil; opPairs = opPairs.Next() {
-			data, err := buildOperationData(
-				opPairs.Key(),
-				pathName,
-				opPairs.Value(),
-				opts,
-				prefix,
-				beta,
-			)
+		if page, ok := pages[replacement.OperationID]; ok {
+			replacement.Link = page.Link
+
+			if page.Title != "" {
+				replacement.Title = page.Title
+			}
+		}
+	}
+}
+
+// getWebhookData generates the MDX stub data for each webhook in the spec.
+func getWebhookData(
+	doc *libopenapi.DocumentModel[v3.Document],
+	opts *Options,
+	plan *utils.OutputPlan,
+	prefix string,
+	beta bool,
+) ([]OperationData, error) {
+	if doc.Model.Webhooks == nil {
+		return nil, nil
+	}
+
+	var result []OperationData
+
+	filter := opts.OperationFilter
+
+	for hookPairs := doc.Model.Webhooks.First(); hookPairs != nil; hookPairs = hookPairs.Next() {
+		name, hook := hookPairs.Key(), hookPairs.Value()
+
+		for opPairs := hook.GetOperations().First(); opPairs != nil; opPairs = opPairs.Next() {
+			skip, err := utils.IsSkipped(name, hook, opPairs.Value(), nil)
+			if err != nil {
+				return nil, fmt.Errorf("webhook %s: %w", name, err)
+			}
+
+			if skip || filter.Excludes(opPairs.Value()) {
+				continue
+			}
+
+			filename := plan.Add(prefix, webhookFilename(name, opPairs.Value()), "webhook "+name)
+
+			if !filter.Selects(opPairs.Value()) {
+				continue
+			}
+
+			data, err := buildOperationData(opPairs.Key(), name, opPairs.Value(), opts, prefix, beta)
 			if err != nil {
 				return nil, err
 			}
 
+			data.APIPath = ""
+			data.OutputFilename = filename
+			data.Webhook = name
+
+			if data.Title == "" {
+				data.Title = name
+			}
+
 			result = append(result, data)
-			count++
 		}
 	}
 
 	return result, nil
 }
 
+// webhookFilename returns the output filename for a webhook operation.
+// Webhooks don't always have an operation ID.
+func webhookFilename(name string, op *v3.Operation) string {
+	if op.OperationId == "" {
+		return fmt.Sprintf("%s.mdx", utils.ToKebabCase(name))
+	}
+
+	return utils.GetOutputFilename(op)
+}
+
+// getCallbacks returns the callbacks of the operation.
+func getCallbacks(op *v3.Operation) []Callback {
+	if op.Callbacks == nil {
+		return nil
+	}
+
+	var result []Callback
+
+	for cbPairs := op.Callbacks.First(); cbPairs != nil; cbPairs = cbPairs.Next() {
+		callback := cbPairs.Value()
+		if callback == nil || callback.Expression == nil {
+			continue
+		}
+
+		for exprPairs := callback.Expression.First(); exprPairs != nil; exprPairs = exprPairs.Next() {
+			for opPairs := exprPairs.Value().GetOperations().First(); opPairs != nil; opPairs = opPairs.Next() {
+				result = append(result, Callback{
+					Name:       cbPairs.Key(),
+					Expression: exprPairs.Key(),
+					Verb:       strings.ToUpper(opPairs.Key()),
+					Summary:    strings.TrimSpace(opPairs.Value().Summary),
+				})
+			}
+		}
+	}
+
+	return result
+}
+
 func buildOperationData(
 	verb, pathName string,
 	op *v3.Operation,
@@ -244,13 +563,20 @@ func buildOperationData(
 		return OperationData{}, fmt.Errorf("get beta status for %s %s: %w", verb, pathName, err)
 	}
 
+	deprecatedBy, err := utils.GetDeprecatedBy(op)
+	if err != nil {
+		return OperationData{}, fmt.Errorf("get replacement for %s %s: %w", verb, pathName, err)
+	}
+
 	data := OperationData{
 		ACL:              utils.AclToString(acl),
 		APIPath:          pathName,
 		Beta:             beta || opBeta,
+		Callbacks:        getCallbacks(op),
+		Deprecated:       (op.Deprecated != nil && *op.Deprecated) || deprecatedBy != "",
 		Description:      long,
 		InputFilename:    normalizePath(opts.InputFileName),
-		OutputFilename:   utils.GetOutputFilename(op),
+		OperationID:      op.OperationId,
 		OutputPath:       prefix,
 		RequiresAdmin:    false,
 		ShortDescription: utils.StripMarkdown(short),
@@ -258,6 +584,15 @@ func buildOperationData(
 		Verb:             verb,
 	}
 
+	if len(op.Tags) > 0 {
+		data.Tag = op.Tags[0]
+	}
+
+	if deprecatedBy != "" {
+		// Without a page, the replacement is shown by its operation ID
+		data.DeprecatedBy = &Replacement{OperationID: deprecatedBy, Title: deprecatedBy}
+	}
+
 	if data.ACL == "`admin`" {
 		data.RequiresAdmin = true
 	}
@@ -273,6 +608,37 @@ func buildOperationData(
 	return data, nil
 }
 
+// Link returns the URL path of the operation's page.
+func (d OperationData) Link() string {
+	return "/" + navigation.PagePath(path.Join(d.OutputPath, d.OutputFilename))
+}
+
+// groupByTag groups the operations by their first tag.
+// Tags are in the order of the spec, followed by undeclared tags in the order of their first use.
+// Operations without tags come first.
+func groupByTag(data []OperationData, tags []string) []OperationGroup {
+	order := append([]string{""}, tags...)
+	operations := map[string][]OperationData{}
+
+	for _, item := range data {
+		if !slices.Contains(order, item.Tag) {
+			order = append(order, item.Tag)
+		}
+
+		operations[item.Tag] = append(operations[item.Tag], item)
+	}
+
+	var result []OperationGroup
+
+	for _, tag := range order {
+		if len(operations[tag]) > 0 {
+			result = append(result, OperationGroup{Tag: tag, Operations: operations[tag]})
+		}
+	}
+
+	return result
+}
+
 // writeOverviewData writes the API's overview data into an MDX file.
 func writeOverviewData(
 	data OverviewData,
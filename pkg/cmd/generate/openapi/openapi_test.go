@@ -2,6 +2,7 @@ package openapi
 
 import (
 	"bytes"
+	"reflect"
 	"strings"
 	"testing"
 	"text/template"
@@ -40,7 +41,7 @@ paths:
 		APIName:         "search",
 		InputFileName:   "specs/search.yml",
 		OutputDirectory: "out",
-	})
+	}, utils.NewOutputPlan(""))
 	if err != nil {
 		t.Fatalf("getAPIData() error = %v", err)
 	}
@@ -85,6 +86,171 @@ paths:
 	})
 }
 
+func TestGetAPIDataIncludesWebhooksAndCallbacks(t *testing.T) {
+	t.Parallel()
+
+	spec := []byte(`openapi: 3.1.0
+info:
+  title: Ingestion API
+  version: 1.0.0
+paths:
+  /2/tasks/{taskID}/run:
+    post:
+      operationId: runTask
+      summary: Run a task
+      callbacks:
+        taskFinished:
+          '{$request.body#/callbackURL}':
+            post:
+              summary: Task finished
+              responses:
+                '200':
+                  description: OK
+      responses:
+        '200':
+          description: OK
+webhooks:
+  taskFailed:
+    post:
+      summary: Task failed
+      responses:
+        '200':
+          description: OK
+`)
+
+	doc, err := utils.LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	data, err := getAPIData(doc, &Options{
+		APIName:         "ingestion",
+		InputFileName:   "specs/ingestion.yml",
+		OutputDirectory: "out",
+	}, utils.NewOutputPlan(""))
+	if err != nil {
+		t.Fatalf("getAPIData() error = %v", err)
+	}
+
+	if len(data) != 2 {
+		t.Fatalf("getAPIData() len = %d, want 2", len(data))
+	}
+
+	webhook := data[1]
+	if webhook.Webhook != "taskFailed" || webhook.OutputFilename != "task-failed.mdx" {
+		t.Fatalf("webhook = %q in %s, want taskFailed in task-failed.mdx", webhook.Webhook, webhook.OutputFilename)
+	}
+
+	tmpl, err := utils.LoadTemplate("", stubTemplateName, stubTemplate)
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+
+	var got bytes.Buffer
+	if err := tmpl.Execute(&got, webhook); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertRenderedContains(t, got.String(), []string{
+		"openapi: /specs/ingestion.yml webhook taskFailed",
+		"title: Task failed",
+	})
+
+	got.Reset()
+
+	if err := tmpl.Execute(&got, data[0]); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertRenderedContains(t, got.String(), []string{
+		"openapi: /specs/ingestion.yml post /2/tasks/{taskID}/run",
+		"## Callbacks\n\n- **taskFinished:** Task finished (`POST {$request.body#/callbackURL}`)",
+	})
+}
+
+func TestGetAPIDataLinksDeprecatedOperationsToReplacements(t *testing.T) {
+	t.Parallel()
+
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+paths:
+  /1/indexes/{indexName}/browse:
+    get:
+      operationId: browseGet
+      summary: Browse (GET)
+      deprecated: true
+      x-deprecated-by: browse
+    post:
+      operationId: browse
+      summary: Browse for records
+  /1/indexes/{indexName}/old:
+    get:
+      operationId: oldOperation
+      summary: Old operation
+      deprecated: true
+  /1/indexes/{indexName}/legacy:
+    get:
+      operationId: legacyOperation
+      summary: Legacy operation
+      x-deprecated-by: removedOperation
+`)
+
+	doc, err := utils.LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	data, err := getAPIData(doc, &Options{
+		APIName:         "search",
+		InputFileName:   "specs/search.yml",
+		OutputDirectory: "doc/rest-api",
+	}, utils.NewOutputPlan(""))
+	if err != nil {
+		t.Fatalf("getAPIData() error = %v", err)
+	}
+
+	if len(data) != 4 {
+		t.Fatalf("getAPIData() len = %d, want 4", len(data))
+	}
+
+	want := []struct {
+		deprecated  bool
+		replacement *Replacement
+	}{
+		{true, &Replacement{OperationID: "browse", Title: "Browse for records", Link: "/doc/rest-api/search/browse"}},
+		{false, nil},
+		{true, nil},
+		{true, &Replacement{OperationID: "removedOperation", Title: "removedOperation"}},
+	}
+
+	for i, w := range want {
+		if data[i].Deprecated != w.deprecated {
+			t.Errorf("%s: Deprecated = %v, want %v", data[i].OperationID, data[i].Deprecated, w.deprecated)
+		}
+
+		if !reflect.DeepEqual(data[i].DeprecatedBy, w.replacement) {
+			t.Errorf("%s: DeprecatedBy = %+v, want %+v", data[i].OperationID, data[i].DeprecatedBy, w.replacement)
+		}
+	}
+
+	tmpl, err := utils.LoadTemplate("", stubTemplateName, stubTemplate)
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+
+	var got bytes.Buffer
+	if err := tmpl.Execute(&got, data[0]); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertRenderedContains(t, got.String(), []string{
+		"deprecated: true",
+		"This operation is **deprecated.** Use [Browse for records](/doc/rest-api/search/browse) instead.",
+	})
+}
+
 func TestGetAPIOverviewDataSplitsDescriptionWhenSummaryMissing(t *testing.T) {
 	t.Parallel()
 
@@ -104,7 +270,7 @@ paths: {}
 		t.Fatalf("LoadSpec() error = %v", err)
 	}
 
-	data, err := getAPIOverviewData(doc, &Options{
+	data, err := getAPIOverviewData(doc, nil, &Options{
 		APIName:         "search",
 		InputFileName:   "specs/search.yml",
 		OutputDirectory: "out",
@@ -125,9 +291,10 @@ paths: {}
 		)
 	}
 
-	tmpl := template.Must(template.New("overview").Funcs(template.FuncMap{
-		"frontmatterString": utils.QuoteFrontmatterString,
-	}).Parse(overviewTemplate))
+	tmpl, err := utils.LoadTemplate("", overviewTemplateName, overviewTemplate)
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
 
 	var rendered bytes.Buffer
 	if err := tmpl.Execute(&rendered, data); err != nil {
@@ -142,6 +309,158 @@ paths: {}
 	})
 }
 
+func TestGetAPIOverviewDataListsOperationsByTag(t *testing.T) {
+	t.Parallel()
+
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+tags:
+  - name: Search
+  - name: Indices
+paths:
+  /1/indexes/{indexName}/settings:
+    get:
+      operationId: getSettings
+      summary: Retrieve index settings
+      tags: [Indices]
+      x-acl: [settings]
+  /1/keys:
+    get:
+      operationId: listApiKeys
+      summary: List API keys
+      x-acl: [admin]
+  /1/indexes/{indexName}/query:
+    post:
+      operationId: searchSingleIndex
+      summary: Search an index
+      tags: [Search]
+      x-acl: [search]
+      x-beta: true
+`)
+
+	doc, err := utils.LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	opts := &Options{
+		APIName:         "search",
+		InputFileName:   "specs/search.yml",
+		OutputDirectory: "doc/rest-api",
+	}
+
+	data, err := getAPIData(doc, opts, utils.NewOutputPlan(""))
+	if err != nil {
+		t.Fatalf("getAPIData() error = %v", err)
+	}
+
+	overview, err := getAPIOverviewData(doc, data, opts)
+	if err != nil {
+		t.Fatalf("getAPIOverviewData() error = %v", err)
+	}
+
+	var tags []string
+	for _, group := range overview.Groups {
+		tags = append(tags, group.Tag)
+	}
+
+	if want := []string{"", "Search", "Indices"}; strings.Join(tags, ",") != strings.Join(want, ",") {
+		t.Fatalf("group tags = %q, want %q", tags, want)
+	}
+
+	tmpl, err := utils.LoadTemplate("", overviewTemplateName, overviewTemplate)
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, overview); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertRenderedContains(t, rendered.String(), []string{
+		"## Operations\n\n| Method | Path | Operation | ACL |",
+		"| <Badge>GET</Badge> | `/1/keys` | [List API keys](/doc/rest-api/search/list-api-keys) | Admin API key |",
+		"### Search\n\n| Method | Path | Operation | ACL |\n| --- | --- | --- | --- |\n" +
+			"| <Badge>POST</Badge> | `/1/indexes/{indexName}/query` | " +
+			"[Search an index](/doc/rest-api/search/search-single-index) <Badge>Beta</Badge> | `search` |",
+		"| <Badge>GET</Badge> | `/1/indexes/{indexName}/settings` | " +
+			"[Retrieve index settings](/doc/rest-api/search/get-settings) | `settings` |",
+	})
+}
+
+func TestGetAPIOverviewDataExplainsHowToConnect(t *testing.T) {
+	t.Parallel()
+
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+servers:
+  - url: https://{appId}.algolia.net
+    variables:
+      appId:
+        default: ALGOLIA_APPLICATION_ID
+        description: Your Algolia application ID.
+  - url: https://{appId}-dsn.algolia.net
+    description: Read requests
+    variables:
+      appId:
+        default: ALGOLIA_APPLICATION_ID
+x-rate-limit: 5000
+x-timeouts:
+  read: 5000
+  write: 30000
+components:
+  securitySchemes:
+    appId:
+      type: apiKey
+      in: header
+      name: X-Algolia-Application-Id
+      description: Your Algolia application ID.
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-Algolia-API-Key
+      description: Your Algolia API key.
+paths: {}
+`)
+
+	doc, err := utils.LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	data, err := getAPIOverviewData(doc, nil, &Options{APIName: "search", OutputDirectory: "out"})
+	if err != nil {
+		t.Fatalf("getAPIOverviewData() error = %v", err)
+	}
+
+	if len(data.Servers) != 2 || len(data.ServerVariables) != 1 {
+		t.Fatalf("got %d servers and %d variables, want 2 and 1", len(data.Servers), len(data.ServerVariables))
+	}
+
+	tmpl, err := utils.LoadTemplate("", overviewTemplateName, overviewTemplate)
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assertRenderedContains(t, rendered.String(), []string{
+		"## Base URLs\n\n- `https://{appId}.algolia.net`\n- `https://{appId}-dsn.algolia.net`: Read requests",
+		"| `appId` | Your Algolia application ID. | `ALGOLIA_APPLICATION_ID` |  |",
+		"- `X-Algolia-Application-Id` header: Your Algolia application ID.",
+		"- `X-Algolia-API-Key` header: Your Algolia API key.",
+		"## Limits\n\n- **Rate limit:** 5000\n- **Timeouts:**\n  - **read:** 5000\n  - **write:** 30000",
+	})
+}
+
 func parseFrontmatter(t *testing.T, rendered string) map[string]any {
 	t.Helper()
 
@@ -0,0 +1,179 @@
+package openapi
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/algolia/docli/pkg/cmd/generate/utils"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"go.yaml.in/yaml/v4"
+)
+
+// Server is a base URL of the API.
+type Server struct {
+	URL         string
+	Description string
+}
+
+// ServerVariable is a placeholder in the base URLs, such as `{appId}`.
+type ServerVariable struct {
+	Name        string
+	Default     string
+	Enum        []string
+	Description string
+}
+
+// AuthScheme describes how to authenticate requests.
+type AuthScheme struct {
+	Name string
+	Type string
+	// In is where the credentials go: header, query, or cookie.
+	In string
+	// Parameter is the name of the header, query parameter, or cookie.
+	Parameter string
+	// Scheme is the HTTP authentication scheme, such as `bearer`.
+	Scheme      string
+	Description string
+}
+
+// Limit is a rate limit or timeout from an extension of the spec.
+// Fields have the properties of limits that are objects.
+type Limit struct {
+	Name   string
+	Value  string
+	Fields []Limit
+}
+
+// getServers returns the base URLs of the API.
+func getServers(doc *v3.Document) []Server {
+	var result []Server
+
+	for _, server := range doc.Servers {
+		if server == nil {
+			continue
+		}
+
+		result = append(result, Server{
+			URL:         server.URL,
+			Description: strings.TrimSpace(server.Description),
+		})
+	}
+
+	return result
+}
+
+// getServerVariables returns the variables of all servers.
+// Servers often share variables, so each variable is only listed once.
+func getServerVariables(doc *v3.Document) []ServerVariable {
+	var result []ServerVariable
+
+	for _, server := range doc.Servers {
+		if server == nil || server.Variables == nil {
+			continue
+		}
+
+		for pair := server.Variables.First(); pair != nil; pair = pair.Next() {
+			name, variable := pair.Key(), pair.Value()
+
+			if variable == nil || slices.ContainsFunc(result, func(v ServerVariable) bool { return v.Name == name }) {
+				continue
+			}
+
+			// Variables are listed in a table, which can't have line breaks
+			result = append(result, ServerVariable{
+				Name:        name,
+				Default:     variable.Default,
+				Enum:        variable.Enum,
+				Description: strings.Join(strings.Fields(variable.Description), " "),
+			})
+		}
+	}
+
+	return result
+}
+
+// getAuthSchemes returns the security schemes of the spec.
+func getAuthSchemes(doc *v3.Document) []AuthScheme {
+	if doc.Components == nil || doc.Components.SecuritySchemes == nil {
+		return nil
+	}
+
+	var result []AuthScheme
+
+	for pair := doc.Components.SecuritySchemes.First(); pair != nil; pair = pair.Next() {
+		scheme := pair.Value()
+		if scheme == nil {
+			continue
+		}
+
+		auth := AuthScheme{
+			Name:        pair.Key(),
+			Type:        scheme.Type,
+			In:          scheme.In,
+			Parameter:   scheme.Name,
+			Scheme:      scheme.Scheme,
+			Description: strings.TrimSpace(scheme.Description),
+		}
+
+		if scheme.Type == "http" {
+			auth.In = "header"
+			auth.Parameter = "Authorization"
+		}
+
+		if auth.Parameter == "" {
+			auth.Parameter = auth.Name
+		}
+
+		result = append(result, auth)
+	}
+
+	return result
+}
+
+// getLimits returns the rate limits and timeouts from the extensions of the spec,
+// for example, `x-rate-limit` or `x-timeouts`.
+func getLimits(doc *v3.Document) []Limit {
+	if doc.Extensions == nil {
+		return nil
+	}
+
+	var result []Limit
+
+	for pair := doc.Extensions.First(); pair != nil; pair = pair.Next() {
+		if !isLimitExtension(pair.Key()) {
+			continue
+		}
+
+		label := strings.NewReplacer("-", " ", "_", " ").Replace(strings.TrimPrefix(pair.Key(), "x-"))
+		result = append(result, newLimit(utils.Capitalize(label), pair.Value()))
+	}
+
+	return result
+}
+
+func isLimitExtension(name string) bool {
+	name = strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(name))
+
+	return strings.Contains(name, "ratelimit") || strings.Contains(name, "timeout")
+}
+
+// newLimit returns the limit for an extension value.
+// The properties of objects become fields.
+func newLimit(name string, node *yaml.Node) Limit {
+	limit := Limit{Name: name}
+
+	if node.Kind != yaml.MappingNode {
+		limit.Value = utils.NodeString(node)
+
+		return limit
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		limit.Fields = append(limit.Fields, Limit{
+			Name:  node.Content[i].Value,
+			Value: utils.NodeString(node.Content[i+1]),
+		})
+	}
+
+	return limit
+}
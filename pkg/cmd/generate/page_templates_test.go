@@ -24,6 +24,7 @@ func TestPageTemplatesHavePublicFrontmatter(t *testing.T) {
 		"pkg/cmd/generate/clients/method.mdx.tmpl",
 		"pkg/cmd/generate/openapi/overview.mdx.tmpl",
 		"pkg/cmd/generate/openapi/stub.mdx.tmpl",
+		"pkg/cmd/generate/schemas/schema.mdx.tmpl",
 		"pkg/cmd/generate/sla/page.mdx.tmpl",
 	}
 
@@ -0,0 +1,314 @@
+package schemas
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"text/template"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/algolia/docli/pkg/cmd/generate/utils"
+	"github.com/algolia/docli/pkg/navigation"
+	"github.com/algolia/docli/pkg/output"
+	"github.com/algolia/docli/pkg/validate"
+	"github.com/pb33f/libopenapi"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/spf13/cobra"
+)
+
+// Options represents the options and flags for this command.
+type Options struct {
+	APIName         string `yaml:"api-name"`
+	InputFilename   string `yaml:"input"`
+	OutputDirectory string `yaml:"output"`
+	Prune           bool   `yaml:"prune"`
+	TemplatesDir    string `yaml:"templates-dir"`
+	OnCollision     string `yaml:"on-collision"`
+}
+
+// SchemaLink is a link to the page of another schema.
+type SchemaLink struct {
+	Name string
+	Link string
+}
+
+// SchemaData holds data relevant to a single schema page.
+type SchemaData struct {
+	// AllOf lists the component schemas this schema combines.
+	AllOf            []SchemaLink
+	APIName          string
+	Description      string
+	Name             string
+	OutputFilename   string
+	OutputPath       string
+	Schema           utils.SchemaField
+	ShortDescription string
+}
+
+const schemaTemplateName = "schema.mdx.tmpl"
+
+//go:embed schema.mdx.tmpl
+var schemaTemplate string
+
+// DefaultTemplates returns the built-in templates of this command by file name.
+func DefaultTemplates() map[string]string {
+	return map[string]string{
+		schemaTemplateName: schemaTemplate,
+	}
+}
+
+// NewSchemasCommand returns a new instance of the `generate schemas` command.
+func NewSchemasCommand() *cobra.Command {
+	opts := &Options{}
+
+	cmd := &cobra.Command{
+		Use:   "schemas <spec>",
+		Short: "Generate MDX files for the schemas of an API",
+		Long: heredoc.Doc(`
+			This command reads an OpenAPI 3 spec and generates one MDX file
+			for each schema in components.schemas.
+			Each page lists the properties of the schema with their types,
+			possible values, defaults, and examples.
+			Schemas that combine (allOf) or choose between (oneOf) other schemas
+			link to their pages, and so do properties that reference other schemas.
+
+			The command records the generated files in a manifest file (.docli-manifest.schemas.json).
+			With --prune, it deletes generated MDX files for schemas that no longer exist.
+			Files that aren't listed in the manifest are never deleted.
+
+			Schema names that only differ in case or separators, such as SearchParams and searchParams,
+			have the same output file. The command lists these schemas and stops before writing files.
+			Use --on-collision suffix to write the later pages to numbered files instead.
+
+			The pages go into a directory named after the API.
+			The API name is the name of the spec file, unless the spec has an x-docli-api-name extension.
+			Use --api-name to set the API name.
+
+			To change the generated pages, use --templates-dir with a directory
+			that has your own schema.mdx.tmpl template.
+		`),
+		Example: heredoc.Doc(`
+			# Run from root of algolia/docs-new
+			docli gen schemas specs/search.yml -o doc/rest-api/schemas
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			opts.InputFilename = args[0]
+
+			printer, err := output.New(cmd)
+			if err != nil {
+				return err
+			}
+
+			return printer.Finish(Run(opts, printer))
+		},
+	}
+
+	cmd.Flags().
+		StringVarP(&opts.OutputDirectory, "output", "o", "out", "Output directory for generated MDX files")
+	cmd.Flags().
+		BoolVar(&opts.Prune, "prune", false, "Delete generated MDX files for schemas that no longer exist")
+	cmd.Flags().
+		StringVar(&opts.TemplatesDir, "templates-dir", "", "Directory with templates that replace the built-in ones")
+	cmd.Flags().
+		StringVar(&opts.APIName, "api-name", "", "Name of the API for the output directory (default: from the spec)")
+	cmd.Flags().
+		StringVar(&opts.OnCollision, "on-collision", utils.OnCollisionFail, "Handle colliding output files: fail or suffix")
+
+	return cmd
+}
+
+// Run runs the `generate schemas` command with the given options.
+// The API name defaults to the x-docli-api-name extension of the spec,
+// or to the name of the spec file.
+func Run(opts *Options, printer *output.Printer) error {
+	if err := validate.ExistingFile(opts.InputFilename, "spec file"); err != nil {
+		return err
+	}
+
+	if err := validate.OutputDir(opts.OutputDirectory, "output directory"); err != nil {
+		return err
+	}
+
+	if err := utils.ValidateOnCollision(opts.OnCollision); err != nil {
+		return err
+	}
+
+	if opts.TemplatesDir != "" {
+		if err := validate.ExistingDir(opts.TemplatesDir, "templates directory"); err != nil {
+			return err
+		}
+	}
+
+	specFile, err := os.ReadFile(opts.InputFilename)
+	if err != nil {
+		return fmt.Errorf("read spec file %s: %w", opts.InputFilename, err)
+	}
+
+	printer.AddInput(opts.InputFilename)
+
+	printer.Infof("Generating schema pages for spec: %s\n", opts.InputFilename)
+	printer.Infof("Writing output in: %s\n", opts.OutputDirectory)
+
+	spec, err := utils.LoadSpec(specFile)
+	if err != nil {
+		return fmt.Errorf("load spec %s: %w", opts.InputFilename, err)
+	}
+
+	opts.APIName, err = utils.ResolveAPIName(&spec.Model, opts.APIName, opts.InputFilename)
+	if err != nil {
+		return err
+	}
+
+	plan := utils.NewOutputPlan(opts.OnCollision)
+	data := getSchemaData(spec, opts, plan)
+
+	if err := plan.Check(printer); err != nil {
+		return err
+	}
+
+	printer.Verbosef("Spec %s has %d schemas.\n", opts.InputFilename, len(data))
+
+	tmpl, err := utils.LoadTemplate(opts.TemplatesDir, schemaTemplateName, schemaTemplate)
+	if err != nil {
+		return err
+	}
+
+	if err := writeSchemaData(data, tmpl, printer); err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+
+	generated := make([]string, 0, len(data))
+	for _, item := range data {
+		generated = append(generated, item.OutputFilename)
+	}
+
+	dir := fmt.Sprintf("%s/%s", opts.OutputDirectory, opts.APIName)
+	if err := utils.UpdateManifest(printer, plan, dir, "schemas", generated, opts.Prune); err != nil {
+		return fmt.Errorf("update manifest: %w", err)
+	}
+
+	return nil
+}
+
+// getSchemaData returns the page data for each schema in components.schemas.
+func getSchemaData(
+	doc *libopenapi.DocumentModel[v3.Document],
+	opts *Options,
+	plan *utils.OutputPlan,
+) []SchemaData {
+	if doc.Model.Components == nil || doc.Model.Components.Schemas == nil {
+		return nil
+	}
+
+	schemas := doc.Model.Components.Schemas
+	prefix := fmt.Sprintf("%s/%s", opts.OutputDirectory, opts.APIName)
+
+	// Collect the pages first, so that schemas can link to schemas that come later
+	filenames := map[string]string{}
+	pages := map[string]string{}
+
+	for pair := schemas.First(); pair != nil; pair = pair.Next() {
+		filename := plan.Add(prefix, schemaFilename(pair.Key()), "schema "+pair.Key())
+
+		filenames[pair.Key()] = filename
+		pages[pair.Key()] = "/" + navigation.PagePath(path.Join(prefix, filename))
+	}
+
+	var result []SchemaData
+
+	for pair := schemas.First(); pair != nil; pair = pair.Next() {
+		name, proxy := pair.Key(), pair.Value()
+
+		field := utils.NewSchemaField(name, proxy, false)
+		linkFields(field.Fields, pages)
+		linkFields(field.Variants, pages)
+
+		short, long := utils.SplitDescription(field.Description)
+
+		data := SchemaData{
+			APIName:          opts.APIName,
+			Description:      long,
+			Name:             name,
+			OutputFilename:   filenames[name],
+			OutputPath:       prefix,
+			Schema:           field,
+			ShortDescription: utils.StripMarkdown(short),
+		}
+
+		if schema := proxy.Schema(); schema != nil {
+			for _, sub := range schema.AllOf {
+				if sub == nil || !sub.IsReference() {
+					continue
+				}
+
+				ref := utils.RefName(sub.GetReference())
+				if link, ok := pages[ref]; ok {
+					data.AllOf = append(data.AllOf, SchemaLink{Name: ref, Link: link})
+				}
+			}
+		}
+
+		result = append(result, data)
+	}
+
+	return result
+}
+
+// linkFields links fields that reference other schemas to their pages.
+// The properties of linked schemas are documented on their own pages.
+func linkFields(fields []utils.SchemaField, pages map[string]string) {
+	for i := range fields {
+		field := &fields[i]
+
+		ref := field.Ref
+		if ref == "" {
+			ref = field.ItemsRef
+		}
+
+		if link, ok := pages[ref]; ok {
+			field.Link = link
+			field.Fields = nil
+			field.Variants = nil
+
+			continue
+		}
+
+		linkFields(field.Fields, pages)
+		linkFields(field.Variants, pages)
+	}
+}
+
+func schemaFilename(name string) string {
+	return fmt.Sprintf("%s.mdx", utils.ToKebabCase(name))
+}
+
+// writeSchemaData writes the schema pages.
+func writeSchemaData(
+	data []SchemaData,
+	template *template.Template,
+	printer *output.Printer,
+) error {
+	for _, item := range data {
+		if !printer.IsDryRun() {
+			if err := os.MkdirAll(item.OutputPath, 0o700); err != nil {
+				return err
+			}
+		}
+
+		fullPath := filepath.Join(item.OutputPath, item.OutputFilename)
+
+		if err := printer.WriteFile(fullPath, func(w io.Writer) error {
+			return template.Execute(w, item)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package schemas
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/algolia/docli/pkg/cmd/generate/utils"
+)
+
+func TestGetSchemaDataLinksSchemas(t *testing.T) {
+	t.Parallel()
+
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    searchResponse:
+      description: Search response. Includes the hits and metadata.
+      allOf:
+        - $ref: '#/components/schemas/baseResponse'
+        - type: object
+          required: [hits]
+          properties:
+            hits:
+              type: array
+              description: Search results.
+              items:
+                $ref: '#/components/schemas/hit'
+            page:
+              type: integer
+              minimum: 0
+              default: 0
+    baseResponse:
+      type: object
+      properties:
+        processingTimeMS:
+          type: integer
+          example: 1
+    hit:
+      type: object
+      properties:
+        objectID:
+          type: string
+`)
+
+	doc, err := utils.LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	data := getSchemaData(doc, &Options{APIName: "search", OutputDirectory: "doc/schemas"}, utils.NewOutputPlan(""))
+	if len(data) != 3 {
+		t.Fatalf("getSchemaData() len = %d, want 3", len(data))
+	}
+
+	response := data[0]
+	if response.OutputFilename != "search-response.mdx" || response.OutputPath != "doc/schemas/search" {
+		t.Fatalf(
+			"output = %s/%s, want doc/schemas/search/search-response.mdx",
+			response.OutputPath,
+			response.OutputFilename,
+		)
+	}
+
+	tmpl, err := utils.LoadTemplate("", schemaTemplateName, schemaTemplate)
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, response); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`description: "Search response."`,
+		"**Includes:** [`baseResponse`](/doc/schemas/search/base-response)",
+		"<ResponseField name=\"processingTimeMS\" type=\"integer\">\n\nExamples: `1`",
+		"<ResponseField name=\"hits\" type=\"array<object>\" required>\nSearch results.\n\nSee [`hit`](/doc/schemas/search/hit).",
+		"<ResponseField name=\"page\" type=\"integer\" default=\"0\">\n\nMinimum: `0`",
+	} {
+		if !strings.Contains(rendered.String(), want) {
+			t.Fatalf("rendered schema missing %q:\n%s", want, rendered.String())
+		}
+	}
+
+	// Properties of linked schemas are on their own pages
+	if strings.Contains(rendered.String(), "objectID") {
+		t.Fatalf("rendered schema expands the linked hit schema:\n%s", rendered.String())
+	}
+}
@@ -18,9 +18,10 @@ import (
 )
 
 type Options struct {
-	DataFile     string
-	Output       string
-	VersionsFile string
+	DataFile     string `yaml:"input"`
+	Output       string `yaml:"output"`
+	VersionsFile string `yaml:"versions-snippets-file"`
+	TemplatesDir string `yaml:"templates-dir"`
 }
 
 // VersionInfo represents the version information for a single version of an API client.
@@ -50,9 +51,18 @@ type ClientEntry struct {
 	Versions []VersionEntry
 }
 
+const pageTemplateName = "page.mdx.tmpl"
+
 //go:embed page.mdx.tmpl
 var pageTemplate string
 
+// DefaultTemplates returns the built-in templates of this command by file name.
+func DefaultTemplates() map[string]string {
+	return map[string]string{
+		pageTemplateName: pageTemplate,
+	}
+}
+
 func NewSLACommand() *cobra.Command {
 	opts := &Options{}
 
@@ -65,6 +75,10 @@ func NewSLACommand() *cobra.Command {
 
 			Use --versions-snippets-file to also generate a snippet file,
 			so you can include the latest client version in the docs.
+
+			To change the generated page, use --templates-dir with a directory
+			that has your own page.mdx.tmpl template.
+			Run 'docli templates export' to start from the built-in template.
 		`),
 		Example: heredoc.Doc(`
 			# Run from root of algolia/docs-new
@@ -73,6 +87,8 @@ func NewSLACommand() *cobra.Command {
 				--versions-snippets-file snippets/sdk/versions.mdx`),
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
 			opts.DataFile = args[0]
 
 			printer, err := output.New(cmd)
@@ -80,7 +96,7 @@ func NewSLACommand() *cobra.Command {
 				return err
 			}
 
-			return runCommand(opts, printer)
+			return printer.Finish(Run(opts, printer))
 		},
 	}
 
@@ -88,11 +104,14 @@ func NewSLACommand() *cobra.Command {
 		StringVarP(&opts.Output, "output", "o", "", "MDX file for listing the supported versions")
 	cmd.Flags().
 		StringVar(&opts.VersionsFile, "versions-snippets-file", "", "Snippet file with latest released version numbers")
+	cmd.Flags().
+		StringVar(&opts.TemplatesDir, "templates-dir", "", "Directory with templates that replace the built-in ones")
 
 	return cmd
 }
 
-func runCommand(opts *Options, printer *output.Printer) error {
+// Run runs the `generate sla` command with the given options.
+func Run(opts *Options, printer *output.Printer) error {
 	if err := validateOptions(opts, printer.IsDryRun()); err != nil {
 		return err
 	}
@@ -103,6 +122,8 @@ func runCommand(opts *Options, printer *output.Printer) error {
 		return fmt.Errorf("read data file %s: %w", opts.DataFile, err)
 	}
 
+	printer.AddInput(opts.DataFile)
+
 	printer.Infof("Generating SLA page for: %s\n", opts.DataFile)
 	logOutputTargets(opts, printer)
 
@@ -113,12 +134,12 @@ func runCommand(opts *Options, printer *output.Printer) error {
 
 	sorted := sortVersions(&data)
 
-	funcMap := template.FuncMap{
-		"capitalize":      utils.Capitalize,
-		"getLanguageName": utils.GetLanguageName,
+	tmpl, err := utils.LoadTemplate(opts.TemplatesDir, pageTemplateName, pageTemplate)
+	if err != nil {
+		return err
 	}
 
-	if err := writePageOutput(opts.Output, sorted, funcMap, printer); err != nil {
+	if err := writePageOutput(opts.Output, sorted, tmpl, printer); err != nil {
 		return err
 	}
 
@@ -181,22 +202,8 @@ func sortVersions(data *Clients) []ClientEntry {
 	return result
 }
 
-func renderPage(
-	w io.Writer,
-	templateString string,
-	data []ClientEntry,
-	funcMap template.FuncMap,
-) error {
-	tmpl, err := template.New("versions").Funcs(funcMap).Parse(templateString)
-	if err != nil {
-		return err
-	}
-
-	if err = tmpl.Execute(w, data); err != nil {
-		return err
-	}
-
-	return nil
+func renderPage(w io.Writer, tmpl *template.Template, data []ClientEntry) error {
+	return tmpl.Execute(w, data)
 }
 
 func validateOptions(opts *Options, dryRun bool) error {
@@ -214,6 +221,12 @@ func validateOptions(opts *Options, dryRun bool) error {
 		}
 	}
 
+	if opts.TemplatesDir != "" {
+		if err := validate.ExistingDir(opts.TemplatesDir, "templates directory"); err != nil {
+			return err
+		}
+	}
+
 	if opts.VersionsFile != "" {
 		if dryRun {
 			if err := validate.OutputFileDryRun(opts.VersionsFile, "versions file"); err != nil {
@@ -248,15 +261,20 @@ func writeVersionsIfNeeded(path string, data []ClientEntry, printer *output.Prin
 func writePageOutput(
 	outputPath string,
 	data []ClientEntry,
-	funcMap template.FuncMap,
+	tmpl *template.Template,
 	printer *output.Printer,
 ) error {
 	if outputPath == "" {
-		return renderPage(os.Stdout, pageTemplate, data, funcMap)
+		stdout, err := printer.Stdout("SLA page")
+		if err != nil {
+			return err
+		}
+
+		return renderPage(stdout, tmpl, data)
 	}
 
 	return printer.WriteFile(outputPath, func(w io.Writer) error {
-		if err := renderPage(w, pageTemplate, data, funcMap); err != nil {
+		if err := renderPage(w, tmpl, data); err != nil {
 			return fmt.Errorf("render page: %w", err)
 		}
 
@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -18,8 +20,9 @@ import (
 )
 
 type Options struct {
-	SnippetsFile    string
-	OutputDirectory string
+	SnippetsFile    string `yaml:"input"`
+	OutputDirectory string `yaml:"output"`
+	OnCollision     string `yaml:"on-collision"`
 }
 
 // NestedMap represents the data from the nested snippet file.
@@ -34,6 +37,10 @@ func NewSnippetsCommand() *cobra.Command {
 		Long: heredoc.Doc(`
 			This command reads a data file with API client usage snippets.
 			It generates an MDX file for each snippet so you can include them in the docs.
+
+			Snippet names that only differ in case or separators have the same output file.
+			The command lists these snippets and stops before writing files.
+			Use --on-collision suffix to write the later snippets to numbered files instead.
 		`),
 		Example: heredoc.Doc(`
 			# Run from root of algolia/docs-new
@@ -41,6 +48,8 @@ func NewSnippetsCommand() *cobra.Command {
 		`),
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
 			opts.SnippetsFile = args[0]
 
 			printer, err := output.New(cmd)
@@ -48,17 +57,20 @@ func NewSnippetsCommand() *cobra.Command {
 				return err
 			}
 
-			return runCommand(opts, printer)
+			return printer.Finish(Run(opts, printer))
 		},
 	}
 
 	cmd.Flags().
 		StringVarP(&opts.OutputDirectory, "output", "o", "out", "Output directory for generated MDX files")
+	cmd.Flags().
+		StringVar(&opts.OnCollision, "on-collision", utils.OnCollisionFail, "Handle colliding output files: fail or suffix")
 
 	return cmd
 }
 
-func runCommand(opts *Options, printer *output.Printer) error {
+// Run runs the `generate snippets` command with the given options.
+func Run(opts *Options, printer *output.Printer) error {
 	if err := validate.ExistingFile(opts.SnippetsFile, "snippets file"); err != nil {
 		return err
 	}
@@ -67,11 +79,17 @@ func runCommand(opts *Options, printer *output.Printer) error {
 		return err
 	}
 
+	if err := utils.ValidateOnCollision(opts.OnCollision); err != nil {
+		return err
+	}
+
 	bytes, err := os.ReadFile(opts.SnippetsFile)
 	if err != nil {
 		return fmt.Errorf("read snippets file %s: %w", opts.SnippetsFile, err)
 	}
 
+	printer.AddInput(opts.SnippetsFile)
+
 	var data NestedMap
 	if err := json.Unmarshal(bytes, &data); err != nil {
 		return fmt.Errorf("parse snippets file %s: %w", opts.SnippetsFile, err)
@@ -81,24 +99,50 @@ func runCommand(opts *Options, printer *output.Printer) error {
 	printer.Infof("Writing output in: %s\n", opts.OutputDirectory)
 
 	rawSnippets := invertSnippets(data)
+	plan := utils.NewOutputPlan(opts.OnCollision)
 
-	for snippet, examples := range rawSnippets {
-		for name, example := range examples {
-			err := writeSnippet(
-				filepath.Join(opts.OutputDirectory, utils.ToKebabCase(snippet)),
-				fmt.Sprintf("%s.mdx", utils.ToCamelCase(name)),
-				generateMarkdownSnippet(example),
-				printer,
-			)
-			if err != nil {
-				return fmt.Errorf("write snippet %s/%s: %w", snippet, name, err)
-			}
+	// Sorted, so that suffixes for colliding snippets are the same in every run
+	var files []snippetFile
+
+	for _, snippet := range slices.Sorted(maps.Keys(rawSnippets)) {
+		dir, err := output.ResolvePath(opts.OutputDirectory, "snippet "+snippet, utils.ToKebabCase(snippet))
+		if err != nil {
+			return err
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(rawSnippets[snippet])) {
+			source := snippet + "/" + name
+
+			files = append(files, snippetFile{
+				dir:      dir,
+				filename: plan.Add(dir, fmt.Sprintf("%s.mdx", utils.ToCamelCase(name)), source),
+				source:   source,
+				example:  rawSnippets[snippet][name],
+			})
+		}
+	}
+
+	if err := plan.Check(printer); err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if err := writeSnippet(f.dir, f.filename, generateMarkdownSnippet(f.example), printer); err != nil {
+			return fmt.Errorf("write snippet %s: %w", f.source, err)
 		}
 	}
 
 	return nil
 }
 
+// snippetFile is the planned output file of a snippet example.
+type snippetFile struct {
+	dir      string
+	filename string
+	source   string
+	example  map[string]string
+}
+
 // generateMarkdownSnippet generates a CodeGroup block.
 func generateMarkdownSnippet(snippet map[string]string) string {
 	var b strings.Builder
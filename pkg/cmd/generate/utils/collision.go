@@ -0,0 +1,171 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/algolia/docli/pkg/output"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// Strategies for output files that would overwrite each other.
+const (
+	// OnCollisionFail stops before writing any file and lists the colliding sources.
+	OnCollisionFail = "fail"
+	// OnCollisionSuffix adds a number to the later files, such as `get-object-2.mdx`.
+	OnCollisionSuffix = "suffix"
+)
+
+// ValidateOnCollision checks the strategy for colliding output files.
+func ValidateOnCollision(strategy string) error {
+	if strategy != "" && strategy != OnCollisionFail && strategy != OnCollisionSuffix {
+		return fmt.Errorf("invalid collision strategy %q, use %s or %s", strategy, OnCollisionFail, OnCollisionSuffix)
+	}
+
+	return nil
+}
+
+// OperationSource describes an operation in the list of colliding output files.
+func OperationSource(verb, pathName string, op *v3.Operation) string {
+	return fmt.Sprintf("%s (%s %s)", op.OperationId, strings.ToUpper(verb), pathName)
+}
+
+// OutputPlan collects the output files of a command with their sources,
+// to find files that would overwrite each other before anything is written.
+// Paths that only differ in case collide, because file systems can be case-insensitive.
+type OutputPlan struct {
+	strategy string
+	files    []plannedFile
+	// invalid has the errors for file names that resolve outside of their directory.
+	invalid []error
+}
+
+type plannedFile struct {
+	dir      string
+	filename string
+	source   string
+	// wanted is the filename before adding a suffix.
+	wanted string
+}
+
+func (f plannedFile) key() string {
+	return strings.ToLower(path.Join(f.dir, f.filename))
+}
+
+// NewOutputPlan returns an empty plan that resolves collisions with the strategy.
+// The default strategy is to fail.
+func NewOutputPlan(strategy string) *OutputPlan {
+	if strategy == "" {
+		strategy = OnCollisionFail
+	}
+
+	return &OutputPlan{strategy: strategy}
+}
+
+// Add plans the file in dir for source, and returns the filename to write it to.
+// The filename is sanitized with output.SafeName, because it comes from the data.
+// With the suffix strategy, files that collide with an earlier file get the first free number.
+func (p *OutputPlan) Add(dir, filename, source string) string {
+	resolved, err := output.ResolvePath(dir, source, filename)
+	if err != nil {
+		p.invalid = append(p.invalid, err)
+
+		return filename
+	}
+
+	filename = filepath.Base(resolved)
+	file := plannedFile{dir: dir, filename: filename, source: source, wanted: filename}
+
+	if p.strategy == OnCollisionSuffix {
+		ext := path.Ext(filename)
+		base := strings.TrimSuffix(filename, ext)
+
+		for n := 2; p.taken(file.key()); n++ {
+			file.filename = fmt.Sprintf("%s-%d%s", base, n, ext)
+		}
+	}
+
+	p.files = append(p.files, file)
+
+	return file.filename
+}
+
+func (p *OutputPlan) taken(key string) bool {
+	return slices.ContainsFunc(p.files, func(f plannedFile) bool { return f.key() == key })
+}
+
+// Unselected returns the planned files in dir that aren't generated,
+// such as the pages of operations that a filter doesn't select.
+func (p *OutputPlan) Unselected(dir string, generated []string) []string {
+	var result []string
+
+	for _, f := range p.files {
+		if f.dir == dir && !slices.Contains(generated, f.filename) {
+			result = append(result, f.filename)
+		}
+	}
+
+	return result
+}
+
+// Check returns an error for invalid file names,
+// or an error that lists all colliding files with their sources.
+// With the suffix strategy, it warns about the files with a suffix instead.
+func (p *OutputPlan) Check(printer *output.Printer) error {
+	if len(p.invalid) > 0 {
+		return errors.Join(p.invalid...)
+	}
+
+	if p.strategy == OnCollisionSuffix {
+		for _, f := range p.files {
+			if f.filename != f.wanted {
+				printer.Warnf("Writing %s to %s, because %s is already used\n",
+					f.source, path.Join(f.dir, f.filename), f.wanted)
+			}
+		}
+
+		return nil
+	}
+
+	var (
+		collisions []string
+		reported   = map[string]bool{}
+	)
+
+	for _, f := range p.files {
+		key := f.key()
+		if reported[key] {
+			continue
+		}
+
+		var sources []string
+
+		for _, other := range p.files {
+			if other.key() == key {
+				sources = append(sources, other.source)
+			}
+		}
+
+		if len(sources) > 1 {
+			reported[key] = true
+
+			collisions = append(collisions, fmt.Sprintf("  %s: %s", path.Join(f.dir, f.filename),
+				strings.Join(sources, ", ")))
+		}
+	}
+
+	if len(collisions) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%d output files have more than one source:\n%s\nrename the sources, or use --on-collision %s",
+		len(collisions),
+		strings.Join(collisions, "\n"),
+		OnCollisionSuffix,
+	)
+}
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOutputPlanFailsOnCollisions(t *testing.T) {
+	t.Parallel()
+
+	plan := NewOutputPlan("")
+	plan.Add("out/search", "get-object.mdx", "getObject (GET /1/indexes/{indexName}/{objectID})")
+	plan.Add("out/search", "get-settings.mdx", "getSettings (GET /1/indexes/{indexName}/settings)")
+	plan.Add("out/search", "get-object.mdx", "get_object (GET /1/objects)")
+	plan.Add("out/search", "Get-Settings.mdx", "GetSettings (POST /1/settings)")
+	plan.Add("out/recommend", "get-object.mdx", "getObject (GET /1/recommend/{objectID})")
+
+	err := plan.Check(nil)
+	if err == nil {
+		t.Fatal("Check() error = nil, want error for colliding files")
+	}
+
+	for _, want := range []string{
+		"2 output files have more than one source",
+		"out/search/get-object.mdx: getObject (GET /1/indexes/{indexName}/{objectID}), get_object (GET /1/objects)",
+		"out/search/get-settings.mdx: getSettings (GET /1/indexes/{indexName}/settings), GetSettings (POST /1/settings)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Check() error = %q, want it to contain %q", err, want)
+		}
+	}
+
+	if strings.Contains(err.Error(), "out/recommend") {
+		t.Errorf("Check() error = %q, want no collision for files in other directories", err)
+	}
+}
+
+func TestOutputPlanAddsSuffixes(t *testing.T) {
+	t.Parallel()
+
+	plan := NewOutputPlan(OnCollisionSuffix)
+
+	got := []string{
+		plan.Add("out", "get-object.mdx", "getObject"),
+		plan.Add("out", "get-object.mdx", "get_object"),
+		plan.Add("out", "get-object-2.mdx", "getObject2"),
+		plan.Add("out", "get-object.mdx", "GetObject"),
+	}
+
+	want := []string{"get-object.mdx", "get-object-2.mdx", "get-object-2-2.mdx", "get-object-3.mdx"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Add() = %v, want %v", got, want)
+	}
+
+	unselected := plan.Unselected("out", []string{"get-object.mdx", "get-object-3.mdx"})
+	if want := []string{"get-object-2.mdx", "get-object-2-2.mdx"}; !reflect.DeepEqual(unselected, want) {
+		t.Errorf("Unselected() = %v, want %v", unselected, want)
+	}
+}
+
+func TestValidateOnCollision(t *testing.T) {
+	t.Parallel()
+
+	for _, strategy := range []string{"", OnCollisionFail, OnCollisionSuffix} {
+		if err := ValidateOnCollision(strategy); err != nil {
+			t.Errorf("ValidateOnCollision(%q) error = %v", strategy, err)
+		}
+	}
+
+	if err := ValidateOnCollision("overwrite"); err == nil {
+		t.Error("ValidateOnCollision(\"overwrite\") error = nil, want error")
+	}
+}
+
+func TestOutputPlanSanitizesFilenames(t *testing.T) {
+	t.Parallel()
+
+	plan := NewOutputPlan("")
+
+	if got := plan.Add("out", "../../evil.mdx", "snippet ../../evil"); got != "..-..-evil.mdx" {
+		t.Errorf("Add() = %q, want %q", got, "..-..-evil.mdx")
+	}
+
+	if err := plan.Check(nil); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	plan.Add("out", "..", "guide ..")
+
+	err := plan.Check(nil)
+	if err == nil || !strings.Contains(err.Error(), `guide ..: invalid output name ".."`) {
+		t.Errorf("Check() error = %v, want error naming the guide", err)
+	}
+}
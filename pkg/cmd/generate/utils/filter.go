@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"fmt"
+	"path"
+	"slices"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"go.yaml.in/yaml/v4"
+)
+
+// OperationFilter selects the operations to generate.
+// Empty lists don't filter.
+// Commands embed it in their options, so the fields have the names of the flags.
+type OperationFilter struct {
+	// IncludeTags selects operations with at least one of the tags.
+	IncludeTags []string `yaml:"include-tag"`
+	// ExcludeTags removes operations with any of the tags.
+	ExcludeTags []string `yaml:"exclude-tag"`
+	// Operations selects operations with an ID that matches one of the glob patterns.
+	Operations []string `yaml:"operation"`
+	// ExcludeExtensions removes operations with any of the extensions, unless it's set to false.
+	ExcludeExtensions []string `yaml:"exclude-extension"`
+	// SkipPaths are the paths of operations that don't get a page.
+	// If nil, it's DefaultSkipPaths.
+	SkipPaths []string `yaml:"skip-path"`
+}
+
+// PlannedOperation is an operation of the spec with its planned page.
+type PlannedOperation struct {
+	Verb      string
+	PathName  string
+	PathItem  *v3.PathItem
+	Operation *v3.Operation
+	Filename  string
+	// Selected is false for operations that the filter doesn't select.
+	Selected bool
+}
+
+// Validate checks the glob patterns for operation IDs.
+func (f OperationFilter) Validate() error {
+	for _, pattern := range f.Operations {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid operation pattern %q: %w", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// IsPartial returns true if the filter selects operations by tag or operation ID.
+// Generating with a partial filter leaves the pages of the other operations alone.
+func (f OperationFilter) IsPartial() bool {
+	return len(f.IncludeTags) > 0 || len(f.ExcludeTags) > 0 || len(f.Operations) > 0
+}
+
+// Selects returns true if the operation matches the tag and operation ID filters.
+func (f OperationFilter) Selects(op *v3.Operation) bool {
+	if len(f.IncludeTags) > 0 && !slices.ContainsFunc(op.Tags, hasTag(f.IncludeTags)) {
+		return false
+	}
+
+	if slices.ContainsFunc(op.Tags, hasTag(f.ExcludeTags)) {
+		return false
+	}
+
+	if len(f.Operations) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(f.Operations, func(pattern string) bool {
+		ok, _ := path.Match(pattern, op.OperationId)
+
+		return ok
+	})
+}
+
+// Excludes returns true if the operation has one of the excluded extensions.
+func (f OperationFilter) Excludes(op *v3.Operation) bool {
+	if op.Extensions == nil {
+		return false
+	}
+
+	for _, name := range f.ExcludeExtensions {
+		node, ok := op.Extensions.Get(name)
+		if !ok {
+			continue
+		}
+
+		// `x-internal: false` is the same as no extension
+		var value bool
+		if node.Kind == yaml.ScalarNode && node.Decode(&value) == nil && !value {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// PlanOperations plans the pages of the operations in the spec in dir and returns them in order.
+// Skipped operations and operations with an excluded extension don't get a page.
+// Operations that the filter doesn't select are planned too,
+// so that the filenames don't depend on the filter.
+func (f OperationFilter) PlanOperations(doc *v3.Document, plan *OutputPlan, dir string) ([]PlannedOperation, error) {
+	if doc.Paths == nil {
+		return nil, nil
+	}
+
+	skipPaths := f.SkipPaths
+	if skipPaths == nil {
+		skipPaths = DefaultSkipPaths
+	}
+
+	var result []PlannedOperation
+
+	for pathPairs := doc.Paths.PathItems.First(); pathPairs != nil; pathPairs = pathPairs.Next() {
+		pathName, pathItem := pathPairs.Key(), pathPairs.Value()
+
+		for opPairs := pathItem.GetOperations().First(); opPairs != nil; opPairs = opPairs.Next() {
+			verb, op := opPairs.Key(), opPairs.Value()
+
+			skip, err := IsSkipped(pathName, pathItem, op, skipPaths)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", verb, pathName, err)
+			}
+
+			if skip || f.Excludes(op) {
+				continue
+			}
+
+			result = append(result, PlannedOperation{
+				Verb:      verb,
+				PathName:  pathName,
+				PathItem:  pathItem,
+				Operation: op,
+				Filename:  plan.Add(dir, GetOutputFilename(op), OperationSource(verb, pathName, op)),
+				Selected:  f.Selects(op),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func hasTag(tags []string) func(string) bool {
+	return func(tag string) bool {
+		return slices.Contains(tags, tag)
+	}
+}
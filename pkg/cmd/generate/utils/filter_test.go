@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestOperationFilter(t *testing.T) {
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+paths:
+  /{path}:
+    get:
+      operationId: customGet
+      tags: [Advanced]
+  /1/indexes/{indexName}/query:
+    post:
+      operationId: searchSingleIndex
+      tags: [Search]
+  /1/indexes/{indexName}/settings:
+    get:
+      operationId: getSettings
+      tags: [Indices]
+    put:
+      operationId: setSettings
+      tags: [Indices]
+  /1/internal/stats:
+    get:
+      operationId: getInternalStats
+      tags: [Indices]
+      x-internal: true
+  /1/indexes/{indexName}/rules:
+    get:
+      operationId: getRules
+      tags: [Rules]
+      x-internal: false
+`)
+
+	doc, err := LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	all := []string{"searchSingleIndex", "getSettings", "setSettings", "getInternalStats", "getRules"}
+
+	tests := []struct {
+		name     string
+		filter   OperationFilter
+		selects  []string
+		excludes []string
+	}{
+		{
+			name:    "no filter",
+			selects: all,
+		},
+		{
+			name:    "include tag",
+			filter:  OperationFilter{IncludeTags: []string{"Indices", "Rules"}},
+			selects: []string{"getSettings", "setSettings", "getInternalStats", "getRules"},
+		},
+		{
+			name:    "exclude tag",
+			filter:  OperationFilter{ExcludeTags: []string{"Indices"}},
+			selects: []string{"searchSingleIndex", "getRules"},
+		},
+		{
+			name:    "operation pattern",
+			filter:  OperationFilter{Operations: []string{"get*", "search*"}},
+			selects: []string{"searchSingleIndex", "getSettings", "getInternalStats", "getRules"},
+		},
+		{
+			name:     "exclude extension",
+			filter:   OperationFilter{ExcludeExtensions: []string{"x-internal"}},
+			selects:  all,
+			excludes: []string{"getInternalStats"},
+		},
+		{
+			name: "combined",
+			filter: OperationFilter{
+				IncludeTags:       []string{"Indices"},
+				Operations:        []string{"get*"},
+				ExcludeExtensions: []string{"x-internal"},
+			},
+			selects:  []string{"getSettings", "getInternalStats"},
+			excludes: []string{"getInternalStats"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var selects, excludes []string
+
+			for pathPairs := doc.Model.Paths.PathItems.First(); pathPairs != nil; pathPairs = pathPairs.Next() {
+				if slices.Contains(DefaultSkipPaths, pathPairs.Key()) {
+					continue
+				}
+
+				for opPairs := pathPairs.Value().GetOperations().First(); opPairs != nil; opPairs = opPairs.Next() {
+					op := opPairs.Value()
+
+					if tt.filter.Selects(op) {
+						selects = append(selects, op.OperationId)
+					}
+
+					if tt.filter.Excludes(op) {
+						excludes = append(excludes, op.OperationId)
+					}
+				}
+			}
+
+			if !reflect.DeepEqual(selects, tt.selects) {
+				t.Errorf("Selects() selected %v, want %v", selects, tt.selects)
+			}
+
+			if !reflect.DeepEqual(excludes, tt.excludes) {
+				t.Errorf("Excludes() excluded %v, want %v", excludes, tt.excludes)
+			}
+		})
+	}
+}
+
+func TestOperationFilterValidate(t *testing.T) {
+	t.Parallel()
+
+	if err := (OperationFilter{Operations: []string{"get*"}}).Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	if err := (OperationFilter{Operations: []string{"get["}}).Validate(); err == nil {
+		t.Fatal("Validate() error = nil, want error for invalid pattern")
+	}
+}
+
+func TestPlanOperations(t *testing.T) {
+	t.Parallel()
+
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+paths:
+  /{path}:
+    get:
+      operationId: customGet
+  /1/indexes/{indexName}:
+    get:
+      operationId: getObject
+      tags: [Records]
+    post:
+      operationId: get-object
+      tags: [Records]
+  /1/internal/stats:
+    get:
+      operationId: getInternalStats
+      x-internal: true
+  /1/indexes/{indexName}/settings:
+    get:
+      operationId: getSettings
+      tags: [Indices]
+`)
+
+	doc, err := LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	filter := OperationFilter{IncludeTags: []string{"Indices"}, ExcludeExtensions: []string{"x-internal"}}
+
+	ops, err := filter.PlanOperations(&doc.Model, NewOutputPlan(OnCollisionSuffix), "out/search")
+	if err != nil {
+		t.Fatalf("PlanOperations() error = %v", err)
+	}
+
+	var got []string
+	for _, op := range ops {
+		got = append(got, fmt.Sprintf("%s %s %s %t", op.Verb, op.PathName, op.Filename, op.Selected))
+	}
+
+	// Operations that aren't selected are planned, so the suffix doesn't depend on the filter
+	want := []string{
+		"get /1/indexes/{indexName} get-object.mdx false",
+		"post /1/indexes/{indexName} get-object-2.mdx false",
+		"get /1/indexes/{indexName}/settings get-settings.mdx true",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PlanOperations() =\n%q\nwant\n%q", got, want)
+	}
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/algolia/docli/pkg/output"
+)
+
+// UpdateManifest records the generated files in the manifest of generator in dir
+// and, with prune, deletes stale files.
+// Planned files that weren't generated, such as the pages of operations that a filter doesn't select,
+// stay in the manifest if they're already listed.
+func UpdateManifest(
+	printer *output.Printer,
+	plan *OutputPlan,
+	dir, generator string,
+	generated []string,
+	prune bool,
+) error {
+	files, err := manifestFiles(dir, generator, generated, plan.Unselected(dir, generated))
+	if err != nil {
+		return err
+	}
+
+	if prune {
+		if err := printer.Prune(dir, generator, files); err != nil {
+			return err
+		}
+	} else {
+		// Stale files stay in the manifest until they're deleted, so that a later run with prune finds them
+		files, err = withExistingFiles(dir, generator, files)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !printer.IsDryRun() {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return err
+		}
+	}
+
+	return printer.WriteManifest(dir, generator, files)
+}
+
+// manifestFiles returns the generated files together with the unselected files
+// that are already listed in the manifest of generator in dir.
+// This keeps the pages of other operations when generating only a part of a spec.
+func manifestFiles(dir, generator string, generated, unselected []string) ([]string, error) {
+	files := slices.Clone(generated)
+	if len(unselected) == 0 {
+		return files, nil
+	}
+
+	previous, err := output.ReadManifest(dir, generator)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range previous {
+		if slices.Contains(unselected, name) && !slices.Contains(files, name) {
+			files = append(files, name)
+		}
+	}
+
+	return files, nil
+}
+
+// withExistingFiles returns the files together with the files in the manifest of generator in dir
+// that still exist.
+func withExistingFiles(dir, generator string, files []string) ([]string, error) {
+	previous, err := output.ReadManifest(dir, generator)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range previous {
+		if slices.Contains(files, name) || name != filepath.Base(name) {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			return nil, fmt.Errorf("stat %s: %w", path, err)
+		}
+
+		files = append(files, name)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/algolia/docli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+func TestManifestFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	manifest := `{"files": ["get-settings.mdx", "old-page.mdx", "search.mdx"]}`
+
+	if err := os.WriteFile(filepath.Join(dir, ".docli-manifest.openapi.json"), []byte(manifest), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	got, err := manifestFiles(dir, "openapi", []string{"search.mdx"}, []string{"get-settings.mdx", "set-settings.mdx"})
+	if err != nil {
+		t.Fatalf("manifestFiles() error = %v", err)
+	}
+
+	want := []string{"search.mdx", "get-settings.mdx"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("manifestFiles() = %v, want %v", got, want)
+	}
+}
+
+func TestUpdateManifestKeepsFilesOfOtherGenerators(t *testing.T) {
+	t.Parallel()
+
+	printer := newTestPrinter(t)
+	dir := t.TempDir()
+
+	for _, name := range []string{"get-index.mdx", "hit.mdx"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	if err := UpdateManifest(printer, NewOutputPlan(""), dir, "openapi", []string{"get-index.mdx"}, false); err != nil {
+		t.Fatalf("UpdateManifest() openapi error = %v", err)
+	}
+
+	if err := UpdateManifest(printer, NewOutputPlan(""), dir, "schemas", []string{"hit.mdx"}, true); err != nil {
+		t.Fatalf("UpdateManifest() schemas error = %v", err)
+	}
+
+	if err := UpdateManifest(printer, NewOutputPlan(""), dir, "openapi", []string{"get-index.mdx"}, true); err != nil {
+		t.Fatalf("UpdateManifest() openapi error = %v", err)
+	}
+
+	for _, name := range []string{"get-index.mdx", "hit.mdx"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Stat(%s) error = %v, want the file of the other generator to be kept", name, err)
+		}
+	}
+
+	for generator, want := range map[string][]string{"openapi": {"get-index.mdx"}, "schemas": {"hit.mdx"}} {
+		got, err := output.ReadManifest(dir, generator)
+		if err != nil {
+			t.Fatalf("ReadManifest() error = %v", err)
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ReadManifest(%s) = %v, want %v", generator, got, want)
+		}
+	}
+}
+
+func TestUpdateManifestPrunesRenamedFilesLater(t *testing.T) {
+	t.Parallel()
+
+	printer := newTestPrinter(t)
+	dir := t.TempDir()
+
+	write := func(name string) {
+		t.Helper()
+
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	update := func(generated []string, prune bool) {
+		t.Helper()
+
+		if err := UpdateManifest(printer, NewOutputPlan(""), dir, "openapi", generated, prune); err != nil {
+			t.Fatalf("UpdateManifest() error = %v", err)
+		}
+	}
+
+	write("get-index.mdx")
+	write("list-indices.mdx")
+	update([]string{"get-index.mdx", "list-indices.mdx"}, false)
+
+	// getIndex is renamed to fetchIndex, and listIndices is deleted by hand
+	write("fetch-index.mdx")
+
+	if err := os.Remove(filepath.Join(dir, "list-indices.mdx")); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+
+	update([]string{"fetch-index.mdx"}, false)
+
+	got, err := output.ReadManifest(dir, "openapi")
+	if err != nil {
+		t.Fatalf("ReadManifest() error = %v", err)
+	}
+
+	if want := []string{"fetch-index.mdx", "get-index.mdx"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ReadManifest() without prune = %v, want %v", got, want)
+	}
+
+	update([]string{"fetch-index.mdx"}, true)
+
+	if _, err := os.Stat(filepath.Join(dir, "get-index.mdx")); !os.IsNotExist(err) {
+		t.Errorf("Stat(get-index.mdx) error = %v, want the renamed page to be pruned", err)
+	}
+
+	got, err = output.ReadManifest(dir, "openapi")
+	if err != nil {
+		t.Fatalf("ReadManifest() error = %v", err)
+	}
+
+	if want := []string{"fetch-index.mdx"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadManifest() after prune = %v, want %v", got, want)
+	}
+}
+
+// newTestPrinter returns a printer with the global flags of the root command.
+func newTestPrinter(t *testing.T) *output.Printer {
+	t.Helper()
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	output.AddFlags(cmd.Flags())
+
+	printer, err := output.New(cmd)
+	if err != nil {
+		t.Fatalf("output.New() error = %v", err)
+	}
+
+	return printer
+}
@@ -0,0 +1,420 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"go.yaml.in/yaml/v4"
+)
+
+// SchemaField describes a schema, or a property of a schema, for the docs.
+type SchemaField struct {
+	Name        string
+	Type        string
+	Format      string
+	Required    bool
+	Deprecated  bool
+	Default     string
+	Enum        []string
+	Minimum     string
+	Maximum     string
+	Examples    []string
+	Description string
+	// Ref is the name of the referenced component schema.
+	Ref string
+	// ItemsRef is the name of the component schema referenced by the items of an array.
+	ItemsRef string
+	// Link is the page that documents the referenced schema, or the schema of the array items.
+	Link string
+	// Fields are the properties of an object, or of the items of an array.
+	Fields []SchemaField
+	// Variants are the alternatives of oneOf and anyOf schemas.
+	Variants []SchemaField
+}
+
+// HasChildren returns true if the field has nested fields, directly or in one of its variants.
+func (f SchemaField) HasChildren() bool {
+	if len(f.Fields) > 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(f.Variants, SchemaField.HasChildren)
+}
+
+// flatSchema is a schema with its allOf subschemas merged into it.
+type flatSchema struct {
+	types        []string
+	format       string
+	description  string
+	deprecated   bool
+	defaultValue *yaml.Node
+	enum         []*yaml.Node
+	minimum      *float64
+	maximum      *float64
+	examples     []*yaml.Node
+	required     []string
+	names        []string
+	properties   map[string]*base.SchemaProxy
+	items        *base.SchemaProxy
+	variants     []*base.SchemaProxy
+}
+
+// NewSchemaField resolves the schema into a tree of fields.
+// It follows references, merges allOf subschemas, and lists oneOf and anyOf alternatives as variants.
+// Recursive references are only expanded once.
+func NewSchemaField(name string, proxy *base.SchemaProxy, required bool) SchemaField {
+	return newSchemaField(name, proxy, required, map[string]bool{})
+}
+
+// NewParameterField returns the field for a path, query, or header parameter.
+// Descriptions and examples of the parameter take precedence over the ones from its schema.
+func NewParameterField(param *v3.Parameter) SchemaField {
+	required := param.Required != nil && *param.Required
+
+	field := NewSchemaField(param.Name, param.Schema, required)
+	field.Deprecated = field.Deprecated || param.Deprecated
+
+	if desc := strings.TrimSpace(param.Description); desc != "" {
+		field.Description = desc
+	}
+
+	var examples []string
+
+	if param.Example != nil {
+		examples = append(examples, NodeString(param.Example))
+	}
+
+	if param.Examples != nil {
+		for pair := param.Examples.First(); pair != nil; pair = pair.Next() {
+			if example := pair.Value(); example != nil && example.Value != nil {
+				examples = append(examples, NodeString(example.Value))
+			}
+		}
+	}
+
+	if len(examples) > 0 {
+		field.Examples = examples
+	}
+
+	return field
+}
+
+// JSONSchema returns the schema of the JSON media type, or of the first media type if there's no JSON.
+func JSONSchema(content *orderedmap.Map[string, *v3.MediaType]) *base.SchemaProxy {
+	if content == nil || content.First() == nil {
+		return nil
+	}
+
+	media, ok := content.Get("application/json")
+	if !ok {
+		media = content.First().Value()
+	}
+
+	if media == nil {
+		return nil
+	}
+
+	return media.Schema
+}
+
+// RefName returns the name of the component a reference points to.
+func RefName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+func newSchemaField(
+	name string,
+	proxy *base.SchemaProxy,
+	required bool,
+	visiting map[string]bool,
+) SchemaField {
+	field := SchemaField{Name: name, Required: required}
+
+	if proxy == nil {
+		return field
+	}
+
+	ref := ""
+	if proxy.IsReference() {
+		ref = proxy.GetReference()
+		field.Ref = RefName(ref)
+	}
+
+	flat := flattenSchema(proxy, visiting)
+	if flat == nil {
+		return field
+	}
+
+	field.Description = strings.TrimSpace(flat.description)
+	field.Format = flat.format
+	field.Deprecated = flat.deprecated
+	field.Default = NodeString(flat.defaultValue)
+	field.Minimum = numberString(flat.minimum)
+	field.Maximum = numberString(flat.maximum)
+
+	for _, value := range flat.enum {
+		field.Enum = append(field.Enum, NodeString(value))
+	}
+
+	for _, value := range flat.examples {
+		field.Examples = append(field.Examples, NodeString(value))
+	}
+
+	// Don't expand a schema inside of itself
+	if ref != "" && visiting[ref] {
+		field.Type = typeName(flat, "")
+
+		return field
+	}
+
+	if ref != "" {
+		visiting[ref] = true
+		defer delete(visiting, ref)
+	}
+
+	for _, propName := range flat.names {
+		field.Fields = append(
+			field.Fields,
+			newSchemaField(
+				propName,
+				flat.properties[propName],
+				slices.Contains(flat.required, propName),
+				visiting,
+			),
+		)
+	}
+
+	for i, variant := range flat.variants {
+		child := newSchemaField("", variant, false, visiting)
+		child.Name = variantName(child, variant, i)
+		field.Variants = append(field.Variants, child)
+	}
+
+	itemType := ""
+
+	if flat.items != nil {
+		item := newSchemaField("", flat.items, false, visiting)
+		itemType = item.Type
+		field.ItemsRef = item.Ref
+		field.Fields = append(field.Fields, item.Fields...)
+		field.Variants = append(field.Variants, item.Variants...)
+	}
+
+	field.Type = typeName(flat, itemType)
+	if field.Type == "" {
+		field.Type = variantTypes(field.Variants)
+	}
+
+	return field
+}
+
+// flattenSchema returns the schema with its allOf subschemas merged into it.
+func flattenSchema(proxy *base.SchemaProxy, visiting map[string]bool) *flatSchema {
+	schema := proxy.Schema()
+	if schema == nil {
+		return nil
+	}
+
+	flat := &flatSchema{
+		types:        schema.Type,
+		format:       schema.Format,
+		description:  schema.Description,
+		deprecated:   schema.Deprecated != nil && *schema.Deprecated,
+		defaultValue: schema.Default,
+		enum:         schema.Enum,
+		minimum:      schema.Minimum,
+		maximum:      schema.Maximum,
+		examples:     slices.Clone(schema.Examples),
+		required:     slices.Clone(schema.Required),
+		properties:   map[string]*base.SchemaProxy{},
+		variants:     slices.Concat(schema.OneOf, schema.AnyOf),
+	}
+
+	if schema.Example != nil {
+		flat.examples = append([]*yaml.Node{schema.Example}, flat.examples...)
+	}
+
+	if schema.Items != nil && schema.Items.IsA() {
+		flat.items = schema.Items.A
+	}
+
+	for _, sub := range schema.AllOf {
+		if sub == nil {
+			continue
+		}
+
+		ref := ""
+		if sub.IsReference() {
+			ref = sub.GetReference()
+		}
+
+		if visiting[ref] {
+			continue
+		}
+
+		if ref != "" {
+			visiting[ref] = true
+		}
+
+		subFlat := flattenSchema(sub, visiting)
+
+		delete(visiting, ref)
+
+		if subFlat != nil {
+			flat.merge(subFlat)
+		}
+	}
+
+	if schema.Properties != nil {
+		for pair := schema.Properties.First(); pair != nil; pair = pair.Next() {
+			flat.addProperty(pair.Key(), pair.Value())
+		}
+	}
+
+	return flat
+}
+
+// merge adds the properties of an allOf subschema.
+// Values of the schema itself take precedence.
+func (f *flatSchema) merge(sub *flatSchema) {
+	if len(f.types) == 0 {
+		f.types = sub.types
+	}
+
+	if f.format == "" {
+		f.format = sub.format
+	}
+
+	if f.description == "" {
+		f.description = sub.description
+	}
+
+	if f.minimum == nil {
+		f.minimum = sub.minimum
+	}
+
+	if f.maximum == nil {
+		f.maximum = sub.maximum
+	}
+
+	if len(f.examples) == 0 {
+		f.examples = sub.examples
+	}
+
+	if f.defaultValue == nil {
+		f.defaultValue = sub.defaultValue
+	}
+
+	if len(f.enum) == 0 {
+		f.enum = sub.enum
+	}
+
+	if f.items == nil {
+		f.items = sub.items
+	}
+
+	f.deprecated = f.deprecated || sub.deprecated
+	f.variants = append(f.variants, sub.variants...)
+
+	for _, name := range sub.required {
+		if !slices.Contains(f.required, name) {
+			f.required = append(f.required, name)
+		}
+	}
+
+	for _, name := range sub.names {
+		f.addProperty(name, sub.properties[name])
+	}
+}
+
+func (f *flatSchema) addProperty(name string, proxy *base.SchemaProxy) {
+	if _, ok := f.properties[name]; !ok {
+		f.names = append(f.names, name)
+	}
+
+	f.properties[name] = proxy
+}
+
+// typeName returns the type of the schema, for example, `string` or `array<object>`.
+func typeName(flat *flatSchema, itemType string) string {
+	var types []string
+
+	for _, t := range flat.types {
+		if t == "null" {
+			continue
+		}
+
+		if t == "array" && itemType != "" {
+			t = fmt.Sprintf("array<%s>", itemType)
+		}
+
+		types = append(types, t)
+	}
+
+	if len(types) == 0 && len(flat.names) > 0 {
+		return "object"
+	}
+
+	return strings.Join(types, " | ")
+}
+
+// variantTypes returns the distinct types of the variants.
+func variantTypes(variants []SchemaField) string {
+	var types []string
+
+	for _, v := range variants {
+		if v.Type != "" && !slices.Contains(types, v.Type) {
+			types = append(types, v.Type)
+		}
+	}
+
+	return strings.Join(types, " | ")
+}
+
+func variantName(field SchemaField, proxy *base.SchemaProxy, index int) string {
+	if field.Ref != "" {
+		return field.Ref
+	}
+
+	if schema := proxy.Schema(); schema != nil && schema.Title != "" {
+		return schema.Title
+	}
+
+	return fmt.Sprintf("Option %d", index+1)
+}
+
+// numberString returns the number as string, or an empty string if it's not set.
+func numberString(n *float64) string {
+	if n == nil {
+		return ""
+	}
+
+	return strconv.FormatFloat(*n, 'f', -1, 64)
+}
+
+// NodeString returns a YAML value as string.
+// Lists and objects use the compact flow style.
+func NodeString(node *yaml.Node) string {
+	if node == nil {
+		return ""
+	}
+
+	if node.Kind == yaml.ScalarNode {
+		return node.Value
+	}
+
+	flow := *node
+	flow.Style = yaml.FlowStyle
+
+	out, err := yaml.Marshal(&flow)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(out))
+}
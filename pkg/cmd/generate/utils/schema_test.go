@@ -0,0 +1,190 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewSchemaField(t *testing.T) {
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+paths:
+  /1/indexes/{indexName}/query:
+    post:
+      operationId: searchSingleIndex
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/searchParams'
+components:
+  schemas:
+    searchParams:
+      allOf:
+        - $ref: '#/components/schemas/baseParams'
+        - type: object
+          properties:
+            mode:
+              type: string
+              description: Search mode.
+              enum: [neuralSearch, keywordSearch]
+              default: keywordSearch
+    baseParams:
+      type: object
+      required: [query]
+      properties:
+        query:
+          type: string
+          description: Search query.
+        facetFilters:
+          oneOf:
+            - type: array
+              items:
+                type: string
+            - type: string
+            - $ref: '#/components/schemas/facetFilter'
+        rules:
+          type: array
+          items:
+            type: object
+            properties:
+              pattern:
+                type: string
+    facetFilter:
+      type: object
+      properties:
+        attribute:
+          type: string
+`)
+
+	doc, err := LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	pathItem, ok := doc.Model.Paths.PathItems.Get("/1/indexes/{indexName}/query")
+	if !ok {
+		t.Fatal("path not found")
+	}
+
+	schema := JSONSchema(pathItem.Post.RequestBody.Content)
+
+	got := NewSchemaField("", schema, true)
+
+	want := SchemaField{
+		Type:     "object",
+		Required: true,
+		Ref:      "searchParams",
+		Fields: []SchemaField{
+			{
+				Name:        "query",
+				Type:        "string",
+				Required:    true,
+				Description: "Search query.",
+			},
+			{
+				Name: "facetFilters",
+				Type: "array<string> | string | object",
+				Variants: []SchemaField{
+					{Name: "Option 1", Type: "array<string>"},
+					{Name: "Option 2", Type: "string"},
+					{
+						Name: "facetFilter",
+						Type: "object",
+						Ref:  "facetFilter",
+						Fields: []SchemaField{
+							{Name: "attribute", Type: "string"},
+						},
+					},
+				},
+			},
+			{
+				Name: "rules",
+				Type: "array<object>",
+				Fields: []SchemaField{
+					{Name: "pattern", Type: "string"},
+				},
+			},
+			{
+				Name:        "mode",
+				Type:        "string",
+				Description: "Search mode.",
+				Default:     "keywordSearch",
+				Enum:        []string{"neuralSearch", "keywordSearch"},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewSchemaField() =\n%#v\nwant\n%#v", got, want)
+	}
+
+	if !got.HasChildren() {
+		t.Error("HasChildren() = false, want true")
+	}
+}
+
+func TestNewParameterField(t *testing.T) {
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+paths:
+  /1/indexes/{indexName}:
+    get:
+      operationId: getSettings
+      parameters:
+        - name: getVersion
+          in: query
+          description: When set to 2, the endpoint returns all settings.
+          schema:
+            type: integer
+            description: Settings version.
+            enum: [1, 2]
+            default: 1
+          examples:
+            latest:
+              value: 2
+`)
+
+	doc, err := LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	pathItem, ok := doc.Model.Paths.PathItems.Get("/1/indexes/{indexName}")
+	if !ok {
+		t.Fatal("path not found")
+	}
+
+	got := NewParameterField(pathItem.Get.Parameters[0])
+
+	want := SchemaField{
+		Name:        "getVersion",
+		Type:        "integer",
+		Default:     "1",
+		Enum:        []string{"1", "2"},
+		Examples:    []string{"2"},
+		Description: "When set to 2, the endpoint returns all settings.",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewParameterField() =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+func TestRefName(t *testing.T) {
+	tests := map[string]string{
+		"#/components/schemas/searchParams": "searchParams",
+		"common.yml#/parameters/IndexName":  "IndexName",
+		"searchParams":                      "searchParams",
+	}
+
+	for ref, want := range tests {
+		if got := RefName(ref); got != want {
+			t.Errorf("RefName(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
@@ -1,15 +1,21 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"text/template"
 	"unicode"
 
 	"github.com/algolia/docli/pkg/dictionary"
+	"github.com/algolia/docli/pkg/output"
 	"github.com/pb33f/libopenapi"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
 	"go.yaml.in/yaml/v4"
 )
 
@@ -46,12 +52,112 @@ var sentenceAbbreviations = map[string]struct{}{
 	"vs.":   {},
 }
 
+// DefaultSkipPaths are the paths of operations that don't get a page.
+// The path `/{path}` is for sending custom HTTP requests with the API clients.
+var DefaultSkipPaths = []string{"/{path}"}
+
+// apiNameAliases maps spec file names to API names that are different.
+var apiNameAliases = map[string]string{
+	// The Analytics API spec is still named after the old name of the API
+	"searchstats": "analytics",
+}
+
 // GetAPIName returns the name of the YAML file without extension as API name.
 func GetAPIName(path string) string {
-	// Have to make an exception for the Analytics API
-	base := filepath.Base(strings.ReplaceAll(path, "searchstats", "analytics"))
+	base := filepath.Base(path)
+	name := strings.TrimSuffix(base, filepath.Ext(base))
+
+	if alias, ok := apiNameAliases[name]; ok {
+		return alias
+	}
+
+	return name
+}
+
+// SpecAPIName returns the API name for the spec.
+// The `x-docli-api-name` extension of the spec takes precedence over the name of the spec file.
+func SpecAPIName(doc *v3.Document, path string) (string, error) {
+	if doc.Extensions != nil {
+		if node, ok := doc.Extensions.Get("x-docli-api-name"); ok {
+			if node.Kind != yaml.ScalarNode || node.Value == "" {
+				return "", errors.New("x-docli-api-name: expected a non-empty string")
+			}
+
+			return node.Value, nil
+		}
+	}
+
+	return GetAPIName(path), nil
+}
+
+// ResolveAPIName returns the API name for the spec at path, unless apiName is set.
+// The API name is the output directory and can come from the spec,
+// so it must be a single path element.
+func ResolveAPIName(doc *v3.Document, apiName, path string) (string, error) {
+	if apiName == "" {
+		name, err := SpecAPIName(doc, path)
+		if err != nil {
+			return "", fmt.Errorf("get API name for %s: %w", path, err)
+		}
+
+		apiName = name
+	}
+
+	return output.SafeName(apiName, "API name")
+}
+
+// IsSkipped returns true if the operation doesn't get a page.
+// That's the case for operations at one of the skipped paths,
+// and for operations with an `x-docli-skip: true` extension on the operation or its path item.
+func IsSkipped(pathName string, pathItem *v3.PathItem, op *v3.Operation, skipPaths []string) (bool, error) {
+	if slices.Contains(skipPaths, pathName) {
+		return true, nil
+	}
+
+	for _, ext := range []*orderedmap.Map[string, *yaml.Node]{pathItem.Extensions, op.Extensions} {
+		if ext == nil {
+			continue
+		}
+
+		node, ok := ext.Get("x-docli-skip")
+		if !ok {
+			continue
+		}
+
+		skip, err := parseBoolNode(node)
+		if err != nil {
+			return false, fmt.Errorf("x-docli-skip: %w", err)
+		}
+
+		if skip {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
 
-	return strings.TrimSuffix(base, filepath.Ext(base))
+// Parameters returns the parameters of the operation and its path item.
+// Operation parameters override path parameters with the same name and location.
+func Parameters(pathItem *v3.PathItem, op *v3.Operation) []*v3.Parameter {
+	var result []*v3.Parameter
+
+	for _, param := range slices.Concat(pathItem.Parameters, op.Parameters) {
+		if param == nil {
+			continue
+		}
+
+		i := slices.IndexFunc(result, func(p *v3.Parameter) bool { return p.In == param.In && p.Name == param.Name })
+		if i != -1 {
+			result[i] = param
+
+			continue
+		}
+
+		result = append(result, param)
+	}
+
+	return result
 }
 
 // IsBetaAPI returns true if the root document has an `x-beta: true` extension.
@@ -65,7 +171,7 @@ func IsBetaAPI(doc *v3.Document) (bool, error) {
 		return false, nil
 	}
 
-	return parseBetaNode(node)
+	return parseBoolNode(node)
 }
 
 // IsBetaOperation returns true if the operation has an `x-beta: true` extension.
@@ -79,10 +185,10 @@ func IsBetaOperation(op *v3.Operation) (bool, error) {
 		return false, nil
 	}
 
-	return parseBetaNode(node)
+	return parseBoolNode(node)
 }
 
-func parseBetaNode(node *yaml.Node) (bool, error) {
+func parseBoolNode(node *yaml.Node) (bool, error) {
 	if node.Kind != yaml.ScalarNode {
 		return false, fmt.Errorf("expected a scalar node, got kind %d", node.Kind)
 	}
@@ -120,6 +226,25 @@ func GetACL(op *v3.Operation) ([]string, error) {
 	return result, nil
 }
 
+// GetDeprecatedBy returns the ID of the operation that replaces the given operation,
+// from the `x-deprecated-by` extension.
+func GetDeprecatedBy(op *v3.Operation) (string, error) {
+	if op.Extensions == nil {
+		return "", nil
+	}
+
+	node, ok := op.Extensions.Get("x-deprecated-by")
+	if !ok {
+		return "", nil
+	}
+
+	if node.Kind != yaml.ScalarNode || node.Value == "" {
+		return "", fmt.Errorf("expected an operation ID, got kind %d", node.Kind)
+	}
+
+	return node.Value, nil
+}
+
 // AclToString returns a comma-separated string of ACL with backticks.
 func AclToString(acl []string) string {
 	backticked := make([]string, len(acl))
@@ -259,6 +384,41 @@ func StripMarkdown(p string) string {
 	return collapseInlineWhitespace(stripped)
 }
 
+// TemplateFuncs returns the functions available in all page templates.
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"capitalize":        Capitalize,
+		"frontmatterString": QuoteFrontmatterString,
+		"getLanguageName":   GetLanguageName,
+		"trim":              strings.TrimSpace,
+		"upper":             strings.ToUpper,
+	}
+}
+
+// LoadTemplate parses the template file with the given name from dir.
+// If dir is empty or doesn't have the file, it parses the built-in fallback.
+func LoadTemplate(dir, name, fallback string) (*template.Template, error) {
+	contents := fallback
+
+	if dir != "" {
+		path := filepath.Join(dir, name)
+
+		custom, err := os.ReadFile(path)
+		if err == nil {
+			contents = string(custom)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("read template %s: %w", path, err)
+		}
+	}
+
+	tmpl, err := template.New(name).Funcs(TemplateFuncs()).Parse(contents)
+	if err != nil {
+		return nil, fmt.Errorf("parse template %s: %w", name, err)
+	}
+
+	return tmpl, nil
+}
+
 // QuoteFrontmatterString returns a double-quoted YAML string scalar.
 func QuoteFrontmatterString(p string) string {
 	escaped := strings.ReplaceAll(p, `\`, `\\`)
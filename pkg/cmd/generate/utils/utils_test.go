@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -254,6 +257,150 @@ func TestGetApiName(t *testing.T) {
 	}
 }
 
+func TestSpecAPIName(t *testing.T) {
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Analytics API
+  version: 1.0.0
+x-docli-api-name: insights-analytics
+paths: {}
+`)
+
+	doc, err := LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	got, err := SpecAPIName(&doc.Model, "specs/searchstats.yml")
+	if err != nil {
+		t.Fatalf("SpecAPIName() error = %v", err)
+	}
+
+	if got != "insights-analytics" {
+		t.Errorf("SpecAPIName() = %q, want %q", got, "insights-analytics")
+	}
+
+	doc.Model.Extensions = nil
+
+	got, err = SpecAPIName(&doc.Model, "specs/searchstats.yml")
+	if err != nil {
+		t.Fatalf("SpecAPIName() error = %v", err)
+	}
+
+	if got != "analytics" {
+		t.Errorf("SpecAPIName() without extension = %q, want %q", got, "analytics")
+	}
+}
+
+func TestResolveAPIName(t *testing.T) {
+	t.Parallel()
+
+	doc, err := LoadSpec([]byte(`openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+x-docli-api-name: ../search
+paths: {}
+`))
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	got, err := ResolveAPIName(&doc.Model, "", "specs/search.yml")
+	if err != nil || got != "..-search" {
+		t.Errorf("ResolveAPIName() = %q, %v, want the name from the spec as a single path element", got, err)
+	}
+
+	got, err = ResolveAPIName(&doc.Model, "recommend", "specs/search.yml")
+	if err != nil || got != "recommend" {
+		t.Errorf("ResolveAPIName() = %q, %v, want the given name", got, err)
+	}
+
+	if _, err := ResolveAPIName(&doc.Model, "..", "specs/search.yml"); err == nil {
+		t.Error("ResolveAPIName() error = nil, want error for ..")
+	}
+}
+
+func TestIsSkipped(t *testing.T) {
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+paths:
+  /{path}:
+    get:
+      operationId: customGet
+  /1/indexes:
+    get:
+      operationId: listIndices
+    post:
+      operationId: createIndex
+      x-docli-skip: true
+  /1/internal:
+    x-docli-skip: true
+    get:
+      operationId: getInternal
+  /1/invalid:
+    get:
+      operationId: getInvalid
+      x-docli-skip: maybe
+`)
+
+	doc, err := LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	want := map[string]bool{
+		"customGet":   true,
+		"listIndices": false,
+		"createIndex": true,
+		"getInternal": true,
+	}
+
+	for pathPairs := doc.Model.Paths.PathItems.First(); pathPairs != nil; pathPairs = pathPairs.Next() {
+		for opPairs := pathPairs.Value().GetOperations().First(); opPairs != nil; opPairs = opPairs.Next() {
+			op := opPairs.Value()
+
+			got, err := IsSkipped(pathPairs.Key(), pathPairs.Value(), op, DefaultSkipPaths)
+			if op.OperationId == "getInvalid" {
+				if err == nil {
+					t.Errorf("IsSkipped(%s) error = nil, want error for non-boolean value", op.OperationId)
+				}
+
+				continue
+			}
+
+			if err != nil {
+				t.Fatalf("IsSkipped(%s) error = %v", op.OperationId, err)
+			}
+
+			if got != want[op.OperationId] {
+				t.Errorf("IsSkipped(%s) = %v, want %v", op.OperationId, got, want[op.OperationId])
+			}
+		}
+	}
+}
+
+func TestParameters(t *testing.T) {
+	t.Parallel()
+
+	indexName := &v3.Parameter{Name: "indexName", In: "path"}
+	pathQuery := &v3.Parameter{Name: "query", In: "query"}
+	opQuery := &v3.Parameter{Name: "query", In: "query"}
+	header := &v3.Parameter{Name: "query", In: "header"}
+
+	pathItem := &v3.PathItem{Parameters: []*v3.Parameter{indexName, pathQuery}}
+	op := &v3.Operation{Parameters: []*v3.Parameter{opQuery, nil, header}}
+
+	got := Parameters(pathItem, op)
+	want := []*v3.Parameter{indexName, opQuery, header}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("Parameters() = %v, want %v", got, want)
+	}
+}
+
 func TestOutputFilename(t *testing.T) {
 	testOp := &v3.Operation{OperationId: "searchSingleIndex"}
 	expected := "search-single-index.mdx"
@@ -586,3 +733,75 @@ func TestGetAcl(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadTemplate(t *testing.T) {
+	dir := t.TempDir()
+
+	custom := `{{ "custom" | capitalize }} {{ trim "  page  " }}`
+	if err := os.WriteFile(filepath.Join(dir, "custom.tmpl"), []byte(custom), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "broken.tmpl"), []byte("{{ .Title "), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		dir      string
+		file     string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "No directory",
+			dir:      "",
+			file:     "custom.tmpl",
+			expected: "built-in",
+		},
+		{
+			name:     "Template from directory",
+			dir:      dir,
+			file:     "custom.tmpl",
+			expected: "Custom page",
+		},
+		{
+			name:     "Missing template falls back",
+			dir:      dir,
+			file:     "other.tmpl",
+			expected: "built-in",
+		},
+		{
+			name:    "Invalid template",
+			dir:     dir,
+			file:    "broken.tmpl",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := LoadTemplate(tt.dir, tt.file, "built-in")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("error expected, but there was none")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			var b strings.Builder
+			if err := tmpl.Execute(&b, nil); err != nil {
+				t.Fatalf("execute template: %v", err)
+			}
+
+			if b.String() != tt.expected {
+				t.Errorf("got %q, expected %q", b.String(), tt.expected)
+			}
+		})
+	}
+}
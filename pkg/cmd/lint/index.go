@@ -0,0 +1,26 @@
+package lint
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/algolia/docli/pkg/cmd/lint/spec"
+	"github.com/spf13/cobra"
+)
+
+func NewLintCmd() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "lint",
+		Short: "Check data files for problems in the generated docs",
+		Long: heredoc.Doc(`
+			Many problems in the generated docs come from the data files.
+			Each command checks a data file and prints what to fix.
+
+			This is useful when running in CI whenever data files are updated.
+
+			See the individual subcommands to learn what you can check.
+		`),
+	}
+
+	command.AddCommand(spec.NewSpecCommand())
+
+	return command
+}
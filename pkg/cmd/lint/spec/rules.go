@@ -0,0 +1,301 @@
+package spec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/algolia/docli/pkg/cmd/generate/utils"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"go.yaml.in/yaml/v4"
+)
+
+// Severity is how serious a finding is.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	// SeverityOff turns a rule off.
+	SeverityOff Severity = "off"
+)
+
+// Finding is a problem in the spec.
+type Finding struct {
+	Rule     string
+	Severity Severity
+	// Line and Column are the location in the spec file, starting at 1, or 0 if unknown.
+	Line    int
+	Column  int
+	Message string
+}
+
+// rule checks the spec for one kind of problem.
+type rule struct {
+	name     string
+	severity Severity
+	check    func(doc *v3.Document, ops []operation) []Finding
+}
+
+// rules are all lint rules with their default severity.
+var rules = []rule{
+	{name: "missing-operation-id", severity: SeverityError, check: checkMissingOperationID},
+	{name: "duplicate-operation-id", severity: SeverityError, check: checkDuplicateOperationID},
+	{name: "duplicate-filename", severity: SeverityError, check: checkDuplicateFilename},
+	{name: "missing-summary", severity: SeverityError, check: checkMissingSummary},
+	{name: "missing-description", severity: SeverityWarning, check: checkMissingDescription},
+	{name: "description-first-sentence", severity: SeverityWarning, check: checkFirstSentence},
+	{name: "invalid-acl", severity: SeverityError, check: checkACL},
+	{name: "invalid-beta", severity: SeverityError, check: checkBeta},
+	{name: "code-sample-language", severity: SeverityError, check: checkCodeSamples},
+}
+
+// operation is an operation of the spec with its location.
+type operation struct {
+	method string
+	path   string
+	op     *v3.Operation
+}
+
+func (o operation) label() string {
+	return strings.ToUpper(o.method) + " " + o.path
+}
+
+// finding returns a finding at the node, or at the operation if the node is nil.
+func (o operation) finding(node *yaml.Node, format string, args ...any) Finding {
+	if node == nil {
+		node = o.node()
+	}
+
+	f := Finding{Message: o.label() + ": " + fmt.Sprintf(format, args...)}
+	if node != nil {
+		f.Line, f.Column = node.Line, node.Column
+	}
+
+	return f
+}
+
+// node returns the YAML node with the method of the operation.
+func (o operation) node() *yaml.Node {
+	low := o.op.GoLow()
+	if low == nil {
+		return nil
+	}
+
+	if low.KeyNode != nil {
+		return low.KeyNode
+	}
+
+	return low.RootNode
+}
+
+// operationIDNode returns the YAML node with the operation ID.
+func (o operation) operationIDNode() *yaml.Node {
+	if low := o.op.GoLow(); low != nil {
+		return low.OperationId.ValueNode
+	}
+
+	return nil
+}
+
+// descriptionNode returns the YAML node with the description.
+func (o operation) descriptionNode() *yaml.Node {
+	if low := o.op.GoLow(); low != nil {
+		return low.Description.ValueNode
+	}
+
+	return nil
+}
+
+func checkMissingOperationID(_ *v3.Document, ops []operation) []Finding {
+	var result []Finding
+
+	for _, o := range ops {
+		if strings.TrimSpace(o.op.OperationId) == "" {
+			result = append(result, o.finding(nil, "missing operationId, the operation has no page"))
+		}
+	}
+
+	return result
+}
+
+func checkDuplicateOperationID(_ *v3.Document, ops []operation) []Finding {
+	var result []Finding
+
+	first := map[string]operation{}
+
+	for _, o := range ops {
+		id := o.op.OperationId
+		if id == "" {
+			continue
+		}
+
+		if prev, ok := first[id]; ok {
+			result = append(result, o.finding(o.operationIDNode(),
+				"operationId %q is already used by %s", id, prev.label()))
+
+			continue
+		}
+
+		first[id] = o
+	}
+
+	return result
+}
+
+// checkDuplicateFilename finds different operation IDs that have the same page,
+// for example `getAPIKey` and `getApiKey`.
+func checkDuplicateFilename(_ *v3.Document, ops []operation) []Finding {
+	var result []Finding
+
+	first := map[string]operation{}
+
+	for _, o := range ops {
+		if o.op.OperationId == "" {
+			continue
+		}
+
+		name := utils.GetOutputFilename(o.op)
+
+		prev, ok := first[name]
+		if !ok {
+			first[name] = o
+
+			continue
+		}
+
+		// Duplicate IDs are reported by their own rule
+		if prev.op.OperationId != o.op.OperationId {
+			result = append(result, o.finding(o.operationIDNode(),
+				"operationId %q has the same page %s as %q", o.op.OperationId, name, prev.op.OperationId))
+		}
+	}
+
+	return result
+}
+
+func checkMissingSummary(_ *v3.Document, ops []operation) []Finding {
+	var result []Finding
+
+	for _, o := range ops {
+		if strings.TrimSpace(o.op.Summary) == "" {
+			result = append(result, o.finding(nil, "missing summary, which is the title of the page"))
+		}
+	}
+
+	return result
+}
+
+func checkMissingDescription(_ *v3.Document, ops []operation) []Finding {
+	var result []Finding
+
+	for _, o := range ops {
+		if strings.TrimSpace(o.op.Description) == "" {
+			result = append(result, o.finding(nil, "missing description"))
+		}
+	}
+
+	return result
+}
+
+// checkFirstSentence finds descriptions that don't start with a complete sentence.
+// The first sentence is the description in the frontmatter of the page.
+func checkFirstSentence(_ *v3.Document, ops []operation) []Finding {
+	var result []Finding
+
+	for _, o := range ops {
+		short, _ := utils.SplitDescription(o.op.Description)
+		if short == "" || strings.HasSuffix(short, ".") || strings.HasSuffix(short, "!") ||
+			strings.HasSuffix(short, "?") {
+			continue
+		}
+
+		result = append(result, o.finding(o.descriptionNode(),
+			"description doesn't start with a sentence that ends with a period: %q", truncate(short, 60)))
+	}
+
+	return result
+}
+
+func checkACL(_ *v3.Document, ops []operation) []Finding {
+	var result []Finding
+
+	for _, o := range ops {
+		if _, err := utils.GetACL(o.op); err != nil {
+			result = append(result, o.finding(extensionNode(o.op, "x-acl"), "invalid x-acl: %v", err))
+		}
+	}
+
+	return result
+}
+
+func checkBeta(doc *v3.Document, ops []operation) []Finding {
+	var result []Finding
+
+	if _, err := utils.IsBetaAPI(doc); err != nil {
+		f := Finding{Message: fmt.Sprintf("invalid x-beta: %v", err)}
+
+		if node, _ := doc.Extensions.Get("x-beta"); node != nil {
+			f.Line, f.Column = node.Line, node.Column
+		}
+
+		result = append(result, f)
+	}
+
+	for _, o := range ops {
+		if _, err := utils.IsBetaOperation(o.op); err != nil {
+			result = append(result, o.finding(extensionNode(o.op, "x-beta"), "invalid x-beta: %v", err))
+		}
+	}
+
+	return result
+}
+
+// checkCodeSamples finds code samples without a language.
+// Without a language, the code samples can't be shown in the right tab.
+func checkCodeSamples(_ *v3.Document, ops []operation) []Finding {
+	var result []Finding
+
+	for _, o := range ops {
+		node := extensionNode(o.op, "x-codeSamples")
+		if node == nil {
+			continue
+		}
+
+		if node.Kind != yaml.SequenceNode {
+			result = append(result, o.finding(node, "x-codeSamples must be a list"))
+
+			continue
+		}
+
+		for i, child := range node.Content {
+			var sample struct {
+				Lang string `yaml:"lang"`
+			}
+
+			if err := child.Decode(&sample); err != nil || strings.TrimSpace(sample.Lang) == "" {
+				result = append(result, o.finding(child, "code sample %d has no lang", i+1))
+			}
+		}
+	}
+
+	return result
+}
+
+func extensionNode(op *v3.Operation, name string) *yaml.Node {
+	if op.Extensions == nil {
+		return nil
+	}
+
+	node, _ := op.Extensions.Get(name)
+
+	return node
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+
+	return string(runes[:n]) + "..."
+}
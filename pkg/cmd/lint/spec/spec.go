@@ -0,0 +1,224 @@
+package spec
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/algolia/docli/pkg/cmd/generate/utils"
+	"github.com/algolia/docli/pkg/output"
+	"github.com/algolia/docli/pkg/validate"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/spf13/cobra"
+)
+
+// Options represents the options and flags for this command.
+type Options struct {
+	InputFilename string
+	// Rules maps rule names to the severity that replaces their default.
+	Rules map[string]string
+}
+
+// NewSpecCommand returns a new instance of the `lint spec` command.
+func NewSpecCommand() *cobra.Command {
+	opts := &Options{}
+
+	cmd := &cobra.Command{
+		Use:   "spec <spec>",
+		Short: "Check an OpenAPI spec for problems in the generated docs",
+		Long: heredoc.Doc(`
+			This command checks an OpenAPI 3 spec for problems
+			that lead to missing or broken pages in the generated docs.
+			It prints each finding with its line and column in the spec file,
+			and adds the findings to the run report.
+
+			Rules and their default severity:
+
+			  missing-operation-id        error    Operations need an operationId for their page
+			  duplicate-operation-id      error    Operation IDs must be unique
+			  duplicate-filename          error    Operation IDs must have different pages,
+			                                       for example, getAPIKey and getApiKey don't
+			  missing-summary             error    The summary is the title of the page
+			  missing-description         warning  Operations should have a description
+			  description-first-sentence  warning  The first sentence of the description
+			                                       must end with a period
+			  invalid-acl                 error    x-acl must be a list of strings
+			  invalid-beta                error    x-beta must be a boolean
+			  code-sample-language        error    Each x-codeSamples entry needs a lang
+
+			Use --rule to change the severity of a rule to error, warning, or off.
+			Operations that aren't documented, such as the custom request operations
+			or operations with x-docli-skip, aren't checked.
+
+			The command fails if there are findings with the error severity.
+		`),
+		Example: heredoc.Doc(`
+			# Run from root of algolia/docs-new
+			docli lint spec specs/search.yml
+
+			# Don't check descriptions
+			docli lint spec specs/search.yml \
+			  --rule missing-description=off,description-first-sentence=off
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			opts.InputFilename = args[0]
+
+			printer, err := output.New(cmd)
+			if err != nil {
+				return err
+			}
+
+			return printer.Finish(runCommand(opts, printer))
+		},
+	}
+
+	cmd.Flags().
+		StringToStringVar(&opts.Rules, "rule", nil, "Severity of rules, for example, missing-description=error")
+
+	return cmd
+}
+
+// runCommand runs the `lint spec` command and prints the findings.
+// The findings are also in the run report.
+func runCommand(opts *Options, printer *output.Printer) error {
+	if err := validate.ExistingFile(opts.InputFilename, "spec file"); err != nil {
+		return err
+	}
+
+	severities, err := ruleSeverities(opts.Rules)
+	if err != nil {
+		return err
+	}
+
+	specFile, err := os.ReadFile(opts.InputFilename)
+	if err != nil {
+		return fmt.Errorf("read spec %s: %w", opts.InputFilename, err)
+	}
+
+	printer.AddInput(opts.InputFilename)
+
+	doc, err := utils.LoadSpec(specFile)
+	if err != nil {
+		return fmt.Errorf("load spec %s: %w", opts.InputFilename, err)
+	}
+
+	findings, err := lint(&doc.Model, severities)
+	if err != nil {
+		return err
+	}
+
+	errorCount := 0
+
+	for _, f := range findings {
+		printer.Findingf("%s:%d:%d: %s: %s (%s)\n",
+			opts.InputFilename, f.Line, f.Column, f.Severity, f.Message, f.Rule)
+
+		if f.Severity == SeverityError {
+			errorCount++
+		}
+	}
+
+	printer.Infof("Found %d errors and %d warnings\n", errorCount, len(findings)-errorCount)
+
+	if errorCount > 0 {
+		return fmt.Errorf("%s has %d errors", opts.InputFilename, errorCount)
+	}
+
+	return nil
+}
+
+// ruleSeverities returns the severity of each rule, with the overrides applied.
+func ruleSeverities(overrides map[string]string) (map[string]Severity, error) {
+	result := make(map[string]Severity, len(rules))
+	for _, r := range rules {
+		result[r.name] = r.severity
+	}
+
+	for name, value := range overrides {
+		if _, ok := result[name]; !ok {
+			return nil, fmt.Errorf("unknown rule %q, use one of: %s", name, strings.Join(ruleNames(), ", "))
+		}
+
+		severity := Severity(value)
+		if !slices.Contains([]Severity{SeverityError, SeverityWarning, SeverityOff}, severity) {
+			return nil, fmt.Errorf("invalid severity %q for rule %s, use error, warning, or off", value, name)
+		}
+
+		result[name] = severity
+	}
+
+	return result, nil
+}
+
+func ruleNames() []string {
+	names := make([]string, len(rules))
+	for i, r := range rules {
+		names[i] = r.name
+	}
+
+	return names
+}
+
+// lint runs the rules that aren't off and returns their findings sorted by location.
+func lint(doc *v3.Document, severities map[string]Severity) ([]Finding, error) {
+	ops, err := getOperations(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Finding
+
+	for _, r := range rules {
+		severity := severities[r.name]
+		if severity == SeverityOff {
+			continue
+		}
+
+		for _, f := range r.check(doc, ops) {
+			f.Rule = r.name
+			f.Severity = severity
+			result = append(result, f)
+		}
+	}
+
+	slices.SortStableFunc(result, func(a, b Finding) int {
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+
+		return a.Column - b.Column
+	})
+
+	return result, nil
+}
+
+// getOperations returns the operations that get a page in the docs.
+func getOperations(doc *v3.Document) ([]operation, error) {
+	if doc.Paths == nil {
+		return nil, nil
+	}
+
+	var result []operation
+
+	for pathPairs := doc.Paths.PathItems.First(); pathPairs != nil; pathPairs = pathPairs.Next() {
+		pathName, pathItem := pathPairs.Key(), pathPairs.Value()
+
+		for opPairs := pathItem.GetOperations().First(); opPairs != nil; opPairs = opPairs.Next() {
+			skip, err := utils.IsSkipped(pathName, pathItem, opPairs.Value(), utils.DefaultSkipPaths)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", opPairs.Key(), pathName, err)
+			}
+
+			if !skip {
+				result = append(result, operation{method: opPairs.Key(), path: pathName, op: opPairs.Value()})
+			}
+		}
+	}
+
+	return result, nil
+}
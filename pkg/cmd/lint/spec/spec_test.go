@@ -0,0 +1,106 @@
+package spec
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/algolia/docli/pkg/cmd/generate/utils"
+)
+
+func TestLint(t *testing.T) {
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Search API
+  version: 1.0.0
+paths:
+  /{path}:
+    get:
+      operationId: customGet
+  /1/keys/{key}:
+    get:
+      operationId: getApiKey
+      summary: Retrieve API key
+      description: Gets the permissions of an API key
+      x-acl: admin
+    put:
+      operationId: getAPIKey
+      summary: Update API key
+      description: Replaces the permissions of an API key.
+  /1/indexes/{indexName}:
+    get:
+      summary: Browse index
+      description: Retrieves all records.
+      x-beta: maybe
+    post:
+      operationId: getAPIKey
+      description: Adds a record.
+      x-codeSamples:
+        - lang: javascript
+          source: client.saveObject()
+        - label: curl
+          source: curl -X POST
+`)
+
+	doc, err := utils.LoadSpec(spec)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	severities, err := ruleSeverities(nil)
+	if err != nil {
+		t.Fatalf("ruleSeverities() error = %v", err)
+	}
+
+	findings, err := lint(&doc.Model, severities)
+	if err != nil {
+		t.Fatalf("lint() error = %v", err)
+	}
+
+	var got []string
+	for _, f := range findings {
+		got = append(got, fmt.Sprintf("%d %s %s", f.Line, f.Severity, f.Rule))
+	}
+
+	// Findings on the same line are in the order of the rules
+	want := []string{
+		"13 warning description-first-sentence",
+		"14 error invalid-acl",
+		"16 error duplicate-filename",
+		"20 error missing-operation-id",
+		"23 error invalid-beta",
+		"24 error missing-summary",
+		"25 error duplicate-operation-id",
+		"25 error duplicate-filename",
+		"30 error code-sample-language",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lint() findings =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestRuleSeverities(t *testing.T) {
+	t.Parallel()
+
+	got, err := ruleSeverities(map[string]string{"missing-description": "off", "missing-summary": "warning"})
+	if err != nil {
+		t.Fatalf("ruleSeverities() error = %v", err)
+	}
+
+	if got["missing-description"] != SeverityOff || got["missing-summary"] != SeverityWarning {
+		t.Errorf("ruleSeverities() = %v, want overrides applied", got)
+	}
+
+	if got["duplicate-operation-id"] != SeverityError {
+		t.Errorf("ruleSeverities() duplicate-operation-id = %s, want default error", got["duplicate-operation-id"])
+	}
+
+	if _, err := ruleSeverities(map[string]string{"no-such-rule": "error"}); err == nil {
+		t.Error("ruleSeverities() error = nil, want error for unknown rule")
+	}
+
+	if _, err := ruleSeverities(map[string]string{"missing-summary": "fatal"}); err == nil {
+		t.Error("ruleSeverities() error = nil, want error for invalid severity")
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/algolia/docli/pkg/cmd/generate"
+	"github.com/algolia/docli/pkg/cmd/lint"
+	"github.com/algolia/docli/pkg/cmd/templates"
 	"github.com/algolia/docli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -42,13 +44,17 @@ func NewRootCmd() *cobra.Command {
 			See the individual commands to learn what you can do with it.
 		`),
 	}
+	// Execute prints the error.
+	// Commands set SilenceUsage once their arguments are valid,
+	// so that failed runs, for example in check mode, only print the error.
+	cmd.SilenceErrors = true
 	cmd.SetHelpTemplate(helpTemplate())
 	cmd.PersistentFlags().BoolP("help", "h", false, "Help for this command")
-	cmd.PersistentFlags().BoolP(output.FlagVerbose, "v", false, "Enable verbose output")
-	cmd.PersistentFlags().BoolP(output.FlagQuiet, "q", false, "Suppress non-error output")
-	cmd.PersistentFlags().Bool(output.FlagDryRun, false, "Preview actions without writing files")
+	output.AddFlags(cmd.PersistentFlags())
 
 	cmd.AddCommand(generate.NewGenerateCmd())
+	cmd.AddCommand(templates.NewTemplatesCmd())
+	cmd.AddCommand(lint.NewLintCmd())
 
 	return cmd
 }
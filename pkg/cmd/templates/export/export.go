@@ -0,0 +1,99 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/algolia/docli/pkg/cmd/generate/changelog"
+	"github.com/algolia/docli/pkg/cmd/generate/clients"
+	"github.com/algolia/docli/pkg/cmd/generate/openapi"
+	"github.com/algolia/docli/pkg/cmd/generate/schemas"
+	"github.com/algolia/docli/pkg/cmd/generate/sla"
+	"github.com/algolia/docli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+type Options struct {
+	OutputDirectory string
+}
+
+func NewExportCommand() *cobra.Command {
+	opts := &Options{}
+
+	cmd := &cobra.Command{
+		Use:   "export <dir>",
+		Short: "Write the built-in page templates to a directory",
+		Long: heredoc.Doc(`
+			This command writes the built-in templates of the openapi, clients,
+			schemas, sla, and changelog commands to a directory.
+			Edit the templates you want to change and delete the others,
+			then pass the directory to the commands with --templates-dir.
+			Templates that aren't in the directory fall back to the built-in ones.
+		`),
+		Example: heredoc.Doc(`
+			# Export the templates and use them with the openapi command
+			docli templates export templates
+			docli gen openapi specs/search.yml -o doc/rest-api --templates-dir templates
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			opts.OutputDirectory = args[0]
+
+			printer, err := output.New(cmd)
+			if err != nil {
+				return err
+			}
+
+			return printer.Finish(runCommand(opts, printer))
+		},
+	}
+
+	return cmd
+}
+
+func runCommand(opts *Options, printer *output.Printer) error {
+	templates := defaultTemplates()
+
+	printer.Infof("Exporting %d templates to: %s\n", len(templates), opts.OutputDirectory)
+
+	if !printer.IsDryRun() {
+		if err := os.MkdirAll(opts.OutputDirectory, 0o755); err != nil {
+			return fmt.Errorf("create directory %s: %w", opts.OutputDirectory, err)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(templates)) {
+		path := filepath.Join(opts.OutputDirectory, name)
+
+		err := printer.WriteFile(path, func(w io.Writer) error {
+			_, err := io.WriteString(w, templates[name])
+
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// defaultTemplates returns the built-in templates of all commands by file name.
+func defaultTemplates() map[string]string {
+	templates := make(map[string]string)
+
+	maps.Copy(templates, changelog.DefaultTemplates())
+	maps.Copy(templates, openapi.DefaultTemplates())
+	maps.Copy(templates, clients.DefaultTemplates())
+	maps.Copy(templates, schemas.DefaultTemplates())
+	maps.Copy(templates, sla.DefaultTemplates())
+
+	return templates
+}
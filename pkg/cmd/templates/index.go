@@ -0,0 +1,24 @@
+package templates
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/algolia/docli/pkg/cmd/templates/export"
+	"github.com/spf13/cobra"
+)
+
+func NewTemplatesCmd() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "templates",
+		Short: "Work with the built-in page templates",
+		Long: heredoc.Doc(`
+			The openapi, clients, schemas, and sla commands render pages with built-in templates.
+			You can replace these templates at runtime with the --templates-dir flag.
+
+			See the individual subcommands to learn what you can do with the templates.
+		`),
+	}
+
+	command.AddCommand(export.NewExportCommand())
+
+	return command
+}
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"fmt"
+	"iter"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"go.yaml.in/yaml/v4"
+)
+
+// DefaultFilename is the name of the project config file.
+const DefaultFilename = "docli.yml"
+
+// Config represents the project config file with all generation jobs.
+type Config struct {
+	Jobs []Job `yaml:"jobs"`
+}
+
+// Job represents one run of a generator.
+// All keys except `name` and `generator` are options for the generator.
+type Job struct {
+	// Optional: name for identifying the job in messages
+	Name string
+	// Generator to run, for example, openapi or snippets
+	Generator string
+
+	options *yaml.Node
+}
+
+// reservedKeys are the keys that describe the job instead of the generator options.
+var reservedKeys = map[string]bool{
+	"name":      true,
+	"generator": true,
+}
+
+// Load reads and parses the config file at path.
+func Load(path string) (*Config, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(contents, &cfg); err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	if len(cfg.Jobs) == 0 {
+		return nil, fmt.Errorf("config file %s has no jobs", path)
+	}
+
+	for i, job := range cfg.Jobs {
+		if job.Generator == "" {
+			return nil, fmt.Errorf("job %d in %s has no generator", i+1, path)
+		}
+	}
+
+	return &cfg, nil
+}
+
+// UnmarshalYAML splits a job into its description and the generator options.
+func (j *Job) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping for the job, got kind %d", node.Line, node.Kind)
+	}
+
+	options := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		if !reservedKeys[key.Value] {
+			options.Content = append(options.Content, key, value)
+
+			continue
+		}
+
+		var s string
+		if err := value.Decode(&s); err != nil {
+			return fmt.Errorf("line %d: decode %s: %w", value.Line, key.Value, err)
+		}
+
+		switch key.Value {
+		case "name":
+			j.Name = s
+		case "generator":
+			j.Generator = s
+		}
+	}
+
+	j.options = options
+
+	return nil
+}
+
+// Label returns the job's name for messages.
+func (j Job) Label() string {
+	if j.Name != "" {
+		return j.Name
+	}
+
+	return j.Generator
+}
+
+// DecodeOptions decodes the generator options of the job into opts,
+// a pointer to a struct with `yaml` tags for the supported options.
+func (j Job) DecodeOptions(opts any) error {
+	if j.options == nil {
+		return nil
+	}
+
+	known := optionNames(opts)
+
+	for i := 0; i < len(j.options.Content); i += 2 {
+		key := j.options.Content[i]
+		if !known[key.Value] {
+			return fmt.Errorf("line %d: unknown option %q", key.Line, key.Value)
+		}
+	}
+
+	if err := j.options.Decode(opts); err != nil {
+		return fmt.Errorf("decode options: %w", err)
+	}
+
+	return nil
+}
+
+// SetDefaults sets the options in opts to the defaults of the flags with the same names,
+// so that options missing from a job have the same defaults as the command's flags.
+// The flags must not be parsed yet.
+func SetDefaults(flags *pflag.FlagSet, opts any) error {
+	v := reflect.ValueOf(opts)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	for name, field := range optionFields(v.Elem()) {
+		flag := flags.Lookup(name)
+		if flag == nil {
+			continue
+		}
+
+		if err := setDefault(field, flag); err != nil {
+			return fmt.Errorf("default for option %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func setDefault(field reflect.Value, flag *pflag.Flag) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(flag.Value.String())
+	case reflect.Bool:
+		value, err := strconv.ParseBool(flag.Value.String())
+		if err != nil {
+			return err
+		}
+
+		field.SetBool(value)
+	case reflect.Slice:
+		slice, ok := flag.Value.(pflag.SliceValue)
+		if !ok || field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported flag type %s", flag.Value.Type())
+		}
+
+		field.Set(reflect.ValueOf(slices.Clone(slice.GetSlice())))
+	default:
+		return fmt.Errorf("unsupported flag type %s", flag.Value.Type())
+	}
+
+	return nil
+}
+
+// optionNames returns the YAML keys of the struct that opts points to.
+// Fields without a `yaml` tag can't be set from the config file.
+func optionNames(opts any) map[string]bool {
+	names := make(map[string]bool)
+
+	t := reflect.TypeOf(opts)
+	if t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		return names
+	}
+
+	for name := range optionFields(reflect.New(t.Elem()).Elem()) {
+		names[name] = true
+	}
+
+	return names
+}
+
+// optionFields returns the fields of the struct v by their YAML keys,
+// including the fields of inlined structs.
+func optionFields(v reflect.Value) iter.Seq2[string, reflect.Value] {
+	return func(yield func(string, reflect.Value) bool) {
+		for i := range v.NumField() {
+			name, flags, _ := strings.Cut(v.Type().Field(i).Tag.Get("yaml"), ",")
+
+			if flags == "inline" && v.Field(i).Kind() == reflect.Struct {
+				for name, field := range optionFields(v.Field(i)) {
+					if !yield(name, field) {
+						return
+					}
+				}
+
+				continue
+			}
+
+			if name == "" || name == "-" {
+				continue
+			}
+
+			if !yield(name, v.Field(i)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+type testOptions struct {
+	Input    string `yaml:"input"`
+	Output   string `yaml:"output"`
+	Prune    bool   `yaml:"prune"`
+	Internal string
+}
+
+func TestLoadSplitsJobOptions(t *testing.T) {
+	path := writeConfig(t, `jobs:
+  - name: Search API
+    generator: openapi
+    input: specs/search.yml
+    output: doc/rest-api
+    prune: true
+  - generator: snippets
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(cfg.Jobs) != 2 {
+		t.Fatalf("len(Jobs) = %d, want 2", len(cfg.Jobs))
+	}
+
+	job := cfg.Jobs[0]
+	if job.Name != "Search API" || job.Generator != "openapi" {
+		t.Fatalf("job = %q/%q, want %q/%q", job.Name, job.Generator, "Search API", "openapi")
+	}
+
+	var opts testOptions
+	if err := job.DecodeOptions(&opts); err != nil {
+		t.Fatalf("DecodeOptions() error = %v", err)
+	}
+
+	want := testOptions{Input: "specs/search.yml", Output: "doc/rest-api", Prune: true}
+	if opts != want {
+		t.Fatalf("options = %+v, want %+v", opts, want)
+	}
+
+	if got := cfg.Jobs[1].Label(); got != "snippets" {
+		t.Fatalf("Label() = %q, want %q", got, "snippets")
+	}
+}
+
+func TestDecodeOptionsRejectsUnknownOptions(t *testing.T) {
+	path := writeConfig(t, `jobs:
+  - generator: openapi
+    input: specs/search.yml
+    outptu: doc/rest-api
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	var opts testOptions
+
+	err = cfg.Jobs[0].DecodeOptions(&opts)
+	if err == nil || !strings.Contains(err.Error(), `line 4: unknown option "outptu"`) {
+		t.Fatalf("DecodeOptions() error = %v, want unknown option error", err)
+	}
+}
+
+func TestDecodeOptionsRejectsUntaggedFields(t *testing.T) {
+	path := writeConfig(t, `jobs:
+  - generator: openapi
+    internal: value
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	var opts testOptions
+	if err := cfg.Jobs[0].DecodeOptions(&opts); err == nil {
+		t.Fatal("expected error for option without yaml tag")
+	}
+}
+
+func TestSetDefaultsUsesFlagDefaults(t *testing.T) {
+	path := writeConfig(t, `jobs:
+  - generator: openapi
+    input: specs/search.yml
+    skip-path: ["/{path}"]
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	flags := pflag.NewFlagSet("openapi", pflag.ContinueOnError)
+	flags.String("output", "out", "output directory")
+	flags.Bool("prune", true, "prune")
+	flags.StringSlice("skip-path", []string{"/1/{path}"}, "skipped paths")
+	flags.StringSlice("operation", nil, "operations")
+
+	var opts struct {
+		Input      string   `yaml:"input"`
+		Output     string   `yaml:"output"`
+		Prune      bool     `yaml:"prune"`
+		SkipPaths  []string `yaml:"skip-path"`
+		Operations []string `yaml:"operation"`
+	}
+
+	if err := SetDefaults(flags, &opts); err != nil {
+		t.Fatalf("SetDefaults() error = %v", err)
+	}
+
+	if err := cfg.Jobs[0].DecodeOptions(&opts); err != nil {
+		t.Fatalf("DecodeOptions() error = %v", err)
+	}
+
+	if opts.Input != "specs/search.yml" || opts.Output != "out" || !opts.Prune {
+		t.Errorf("options = %+v, want input from the job and output and prune from the flags", opts)
+	}
+
+	if !reflect.DeepEqual(opts.SkipPaths, []string{"/{path}"}) || opts.Operations != nil {
+		t.Errorf("options = %+v, want skip-path from the job and no operations", opts)
+	}
+}
+
+func TestDecodeOptionsInlinesStructs(t *testing.T) {
+	path := writeConfig(t, `jobs:
+  - generator: openapi
+    input: specs/search.yml
+    include-tag: [Search]
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	type filter struct {
+		IncludeTags []string `yaml:"include-tag"`
+		SkipPaths   []string `yaml:"skip-path"`
+	}
+
+	var opts struct {
+		Input  string `yaml:"input"`
+		filter `yaml:",inline"`
+	}
+
+	flags := pflag.NewFlagSet("openapi", pflag.ContinueOnError)
+	flags.StringSlice("skip-path", []string{"/{path}"}, "skipped paths")
+
+	if err := SetDefaults(flags, &opts); err != nil {
+		t.Fatalf("SetDefaults() error = %v", err)
+	}
+
+	if err := cfg.Jobs[0].DecodeOptions(&opts); err != nil {
+		t.Fatalf("DecodeOptions() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(opts.IncludeTags, []string{"Search"}) ||
+		!reflect.DeepEqual(opts.SkipPaths, []string{"/{path}"}) {
+		t.Errorf("options = %+v, want include-tag from the job and skip-path from the flags", opts)
+	}
+}
+
+func TestLoadRequiresGenerator(t *testing.T) {
+	path := writeConfig(t, `jobs:
+  - name: Missing generator
+    input: specs/search.yml
+`)
+
+	if _, err := Load(path); err == nil {
+		t.Fatal("expected error for job without generator")
+	}
+}
+
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), DefaultFilename)
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	return path
+}
@@ -1,5 +1,10 @@
 package dictionary
 
+import (
+	"regexp"
+	"strings"
+)
+
 // dictionary contains strings with specific spelling or capitalization.
 var dictionary = map[string]string{
 	"csharp":     "C#",
@@ -18,6 +23,31 @@ var normalizedLanguages = map[string]string{
 	"cURL":       "sh",
 }
 
+// NameCase is the naming convention for methods and parameters in an API client.
+type NameCase int
+
+const (
+	// CamelCase names look like searchSingleIndex.
+	CamelCase NameCase = iota
+	// PascalCase names look like SearchSingleIndex.
+	PascalCase
+	// SnakeCase names look like search_single_index.
+	SnakeCase
+)
+
+// methodNameCases associates languages with the naming convention of their API client methods.
+// Languages that aren't listed use camelCase.
+var methodNameCases = map[string]NameCase{
+	"cs":     PascalCase,
+	"csharp": PascalCase,
+	"go":     PascalCase,
+	"python": SnakeCase,
+	"ruby":   SnakeCase,
+}
+
+// wordBoundaryPattern matches the start of a new word in camelCase and PascalCase names.
+var wordBoundaryPattern = regexp.MustCompile(`([a-z0-9])([A-Z])|([A-Z])([A-Z][a-z])`)
+
 // Translate returns the translated string if it's present in the dictionary, the original otherwise.
 func Translate(s string) string {
 	if dictWord, ok := dictionary[s]; ok {
@@ -35,3 +65,44 @@ func NormalizeLang(s string) string {
 
 	return s
 }
+
+// MethodNameCase returns the naming convention for methods in the API client of the language.
+func MethodNameCase(lang string) NameCase {
+	return methodNameCases[lang]
+}
+
+// MethodName returns the name of the method in the API client of the language.
+func MethodName(lang, name string) string {
+	return ToCase(name, MethodNameCase(lang))
+}
+
+// ArgumentName returns the name of a method argument in the API client of the language.
+// Arguments use camelCase, unless the language uses snake_case.
+func ArgumentName(lang, name string) string {
+	if MethodNameCase(lang) == SnakeCase {
+		return ToCase(name, SnakeCase)
+	}
+
+	return ToCase(name, CamelCase)
+}
+
+// ToCase converts a name in camelCase, PascalCase, snake_case, or kebab-case to the given case.
+func ToCase(name string, c NameCase) string {
+	spaced := wordBoundaryPattern.ReplaceAllString(name, "${1}${3} ${2}${4}")
+
+	words := strings.FieldsFunc(strings.ToLower(spaced), func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-'
+	})
+
+	if c == SnakeCase {
+		return strings.Join(words, "_")
+	}
+
+	for i, w := range words {
+		if i > 0 || c == PascalCase {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+
+	return strings.Join(words, "")
+}
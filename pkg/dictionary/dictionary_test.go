@@ -73,3 +73,65 @@ func TestNormalize(t *testing.T) {
 		})
 	}
 }
+
+func TestMethodName(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     string
+		method   string
+		expected string
+	}{
+		{
+			name:     "Python uses snake_case",
+			lang:     "python",
+			method:   "searchSingleIndex",
+			expected: "search_single_index",
+		},
+		{
+			name:     "Go uses PascalCase",
+			lang:     "go",
+			method:   "searchSingleIndex",
+			expected: "SearchSingleIndex",
+		},
+		{
+			name:     "C# uses PascalCase",
+			lang:     "csharp",
+			method:   "getObjectsWithHTTPInfo",
+			expected: "GetObjectsWithHttpInfo",
+		},
+		{
+			name:     "Other languages use camelCase",
+			lang:     "kotlin",
+			method:   "SearchSingleIndex",
+			expected: "searchSingleIndex",
+		},
+		{
+			name:     "Ruby with acronym",
+			lang:     "ruby",
+			method:   "getObjectsWithHTTPInfo",
+			expected: "get_objects_with_http_info",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := MethodName(tt.lang, tt.method)
+
+			if got != tt.expected {
+				t.Errorf("Error in method name: got %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestArgumentName(t *testing.T) {
+	if got := ArgumentName("python", "indexName"); got != "index_name" {
+		t.Errorf("Error in argument name: got %s, expected index_name", got)
+	}
+
+	if got := ArgumentName("go", "indexName"); got != "indexName" {
+		t.Errorf("Error in argument name: got %s, expected indexName", got)
+	}
+}
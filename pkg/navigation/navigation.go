@@ -0,0 +1,204 @@
+// Package navigation builds navigation groups for the Mintlify docs.json file.
+package navigation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Group is a navigation group in the docs.json file.
+// Pages are page paths or nested groups.
+type Group struct {
+	Group string `json:"group"`
+	Pages []any  `json:"pages"`
+}
+
+// PagePath returns the page path for the docs.json file from the path of an MDX file.
+func PagePath(file string) string {
+	page := filepath.ToSlash(filepath.Clean(file))
+	page = strings.TrimPrefix(page, "/")
+
+	return strings.TrimSuffix(page, ".mdx")
+}
+
+// Encode writes the value as indented JSON.
+func Encode(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(v)
+}
+
+// Merge replaces the pages of the group with the same name in the docs.json contents.
+// Only the list of pages changes, so the rest of the file keeps its formatting.
+// The new pages are indented like the line of the list they replace,
+// or on one line if the list was on one line.
+// The docs.json file must have exactly one group with that name, and the group must have pages.
+func Merge(docs []byte, group *Group) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(docs))
+	dec.UseNumber()
+
+	finder := &groupFinder{docs: docs, dec: dec, name: group.Group}
+
+	if _, err := finder.value(); err != nil {
+		return nil, fmt.Errorf("parse JSON: %w", err)
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("parse JSON: unexpected data after the top-level value")
+	}
+
+	if len(finder.pages) == 0 {
+		return nil, fmt.Errorf("no navigation group %q, add the group to docs.json first", group.Group)
+	}
+
+	if len(finder.pages) > 1 {
+		return nil, fmt.Errorf("%d navigation groups named %q, expected one", len(finder.pages), group.Group)
+	}
+
+	loc := finder.pages[0]
+	if loc == nil {
+		return nil, fmt.Errorf("navigation group %q has no pages", group.Group)
+	}
+
+	pages, err := encodePages(docs, *loc, group.Pages)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Concat(docs[:loc.start], pages, docs[loc.end:]), nil
+}
+
+// span is the location of a JSON value in the input.
+type span struct {
+	start int
+	end   int
+}
+
+// groupFinder finds the pages of the navigation groups with a name in a JSON document.
+type groupFinder struct {
+	docs []byte
+	dec  *json.Decoder
+	name string
+	// pages has the location of the pages of each group with the name,
+	// or nil for a group without pages.
+	pages []*span
+}
+
+// value decodes the next JSON value and returns it if it's a string, number, boolean, or null.
+// It returns nil for objects and lists.
+func (f *groupFinder) value() (any, error) {
+	tok, err := f.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil
+	}
+
+	switch delim {
+	case '{':
+		var (
+			name  any
+			pages *span
+		)
+
+		for f.dec.More() {
+			keyTok, err := f.dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			start := f.valueStart()
+
+			value, err := f.value()
+			if err != nil {
+				return nil, err
+			}
+
+			switch keyTok {
+			case "group":
+				name = value
+			case "pages":
+				pages = &span{start: start, end: int(f.dec.InputOffset())}
+			}
+		}
+
+		if name == f.name {
+			f.pages = append(f.pages, pages)
+		}
+
+		_, err := f.dec.Token()
+
+		return nil, err
+	case '[':
+		for f.dec.More() {
+			if _, err := f.value(); err != nil {
+				return nil, err
+			}
+		}
+
+		_, err := f.dec.Token()
+
+		return nil, err
+	default:
+		return nil, fmt.Errorf("unexpected %s", delim)
+	}
+}
+
+// valueStart returns the offset of the value after the last object key.
+func (f *groupFinder) valueStart() int {
+	offset := int(f.dec.InputOffset())
+	for offset < len(f.docs) && strings.IndexByte(" \t\r\n:", f.docs[offset]) != -1 {
+		offset++
+	}
+
+	return offset
+}
+
+// encodePages encodes the pages in the format of the list at loc in docs.
+func encodePages(docs []byte, loc span, pages []any) ([]byte, error) {
+	if pages == nil {
+		pages = []any{}
+	}
+
+	var b bytes.Buffer
+
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+
+	if bytes.IndexByte(docs[loc.start:loc.end], '\n') != -1 {
+		line := docs[bytes.LastIndexByte(docs[:loc.start], '\n')+1 : loc.start]
+		enc.SetIndent(string(leadingSpace(line)), indentUnit(docs))
+	}
+
+	if err := enc.Encode(pages); err != nil {
+		return nil, fmt.Errorf("encode pages: %w", err)
+	}
+
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n")), nil
+}
+
+// indentUnit returns the indentation of the first indented line, which is one level deep.
+func indentUnit(docs []byte) string {
+	for line := range bytes.Lines(docs) {
+		if indent := leadingSpace(line); len(indent) > 0 && len(bytes.TrimSpace(line)) > 0 {
+			return string(indent)
+		}
+	}
+
+	return "  "
+}
+
+func leadingSpace(line []byte) []byte {
+	return line[:len(line)-len(bytes.TrimLeft(line, " \t"))]
+}
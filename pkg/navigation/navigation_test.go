@@ -0,0 +1,178 @@
+package navigation
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPagePath(t *testing.T) {
+	tests := map[string]string{
+		"doc/rest-api/search.mdx":                       "doc/rest-api/search",
+		"./doc/rest-api/search/search-single-index.mdx": "doc/rest-api/search/search-single-index",
+		"/doc/rest-api/search/":                         "doc/rest-api/search",
+	}
+
+	for file, want := range tests {
+		if got := PagePath(file); got != want {
+			t.Errorf("PagePath(%q) = %q, want %q", file, got, want)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	group := &Group{
+		Group: "Search API",
+		Pages: []any{
+			"doc/rest-api/search",
+			&Group{Group: "Search & browse", Pages: []any{"doc/rest-api/search/browse"}},
+		},
+	}
+
+	var b bytes.Buffer
+	if err := Encode(&b, group); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	want := `{
+  "group": "Search API",
+  "pages": [
+    "doc/rest-api/search",
+    {
+      "group": "Search & browse",
+      "pages": [
+        "doc/rest-api/search/browse"
+      ]
+    }
+  ]
+}
+`
+	if b.String() != want {
+		t.Errorf("Encode() =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	// Only the pages of the group change, the rest of the file keeps its formatting
+	docs := []byte(`{
+    "theme": "mint",
+    "name": "Alg\u00f6lia",
+    "navigation": {
+        "tabs": [
+            {
+                "tab": "REST API",
+                "groups": [
+                    {
+                        "group": "Search API",
+                        "icon": "search",
+                        "pages": [
+                            "old/page"
+                        ]
+                    },
+                    {"group": "Other", "pages": ["other/page"], "expanded": false}
+                ]
+            }
+        ]
+    },
+    "colors": {"primary": "#003DFF"},
+    "version": 1.5
+}`)
+
+	group := &Group{
+		Group: "Search API",
+		Pages: []any{
+			"doc/rest-api/search",
+			&Group{Group: "Search & browse", Pages: []any{"doc/rest-api/search/browse"}},
+		},
+	}
+
+	got, err := Merge(docs, group)
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	want := `{
+    "theme": "mint",
+    "name": "Alg\u00f6lia",
+    "navigation": {
+        "tabs": [
+            {
+                "tab": "REST API",
+                "groups": [
+                    {
+                        "group": "Search API",
+                        "icon": "search",
+                        "pages": [
+                            "doc/rest-api/search",
+                            {
+                                "group": "Search & browse",
+                                "pages": [
+                                    "doc/rest-api/search/browse"
+                                ]
+                            }
+                        ]
+                    },
+                    {"group": "Other", "pages": ["other/page"], "expanded": false}
+                ]
+            }
+        ]
+    },
+    "colors": {"primary": "#003DFF"},
+    "version": 1.5
+}`
+	if string(got) != want {
+		t.Errorf("Merge() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMergeKeepsListsOnOneLine(t *testing.T) {
+	docs := []byte(`{"groups": [{"group": "Other", "pages": []}, {"pages": ["old/page"], "group": "Search API"}]}`)
+
+	got, err := Merge(docs, &Group{Group: "Search API", Pages: []any{"doc/rest-api/search"}})
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	want := `{"groups": [{"group": "Other", "pages": []}, {"pages": ["doc/rest-api/search"], "group": "Search API"}]}`
+	if string(got) != want {
+		t.Errorf("Merge() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMergeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		docs    string
+		wantErr string
+	}{
+		{
+			name:    "Missing group",
+			docs:    `{"navigation": {"groups": [{"group": "Other", "pages": []}]}}`,
+			wantErr: `no navigation group "Search API"`,
+		},
+		{
+			name:    "Duplicate group",
+			docs:    `{"groups": [{"group": "Search API", "pages": []}, {"group": "Search API", "pages": []}]}`,
+			wantErr: `2 navigation groups named "Search API"`,
+		},
+		{
+			name:    "Group without pages",
+			docs:    `{"groups": [{"group": "Search API", "openapi": "specs/search.yml"}]}`,
+			wantErr: `navigation group "Search API" has no pages`,
+		},
+		{
+			name:    "Invalid JSON",
+			docs:    `{"navigation": `,
+			wantErr: "parse JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Merge([]byte(tt.docs), &Group{Group: "Search API"})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Merge() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+const (
+	colorReset = "\033[0m"
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+	colorCyan  = "\033[36m"
+)
+
+type diffOp int
+
+const (
+	opEqual diffOp = iota
+	opDelete
+	opInsert
+)
+
+type diffLine struct {
+	op   diffOp
+	text string
+}
+
+// unifiedDiff returns the differences between two texts in unified diff format.
+// It returns an empty string if the texts are equal.
+func unifiedDiff(oldName, newName, oldText, newText string, color bool) string {
+	if oldText == newText {
+		return ""
+	}
+
+	lines := diffLines(splitLines(oldText), splitLines(newText))
+
+	var b strings.Builder
+
+	writeColored(&b, fmt.Sprintf("--- %s\n+++ %s\n", oldName, newName), "", color)
+
+	for _, h := range hunks(lines) {
+		writeHunk(&b, lines, h, color)
+	}
+
+	return b.String()
+}
+
+// splitLines splits a text into lines, keeping the line endings.
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// diffLines computes the edit script from a to b using the longest common subsequence.
+func diffLines(a, b []string) []diffLine {
+	// Strip the common prefix and suffix to keep the LCS table small
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	result := make([]diffLine, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		result = append(result, diffLine{opEqual, line})
+	}
+
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+
+	// lcs[i][j] is the length of the LCS of midA[i:] and midB[j:]
+	lcs := make([][]int, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(midB)+1)
+	}
+
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(midA) || j < len(midB) {
+		switch {
+		case i < len(midA) && j < len(midB) && midA[i] == midB[j]:
+			result = append(result, diffLine{opEqual, midA[i]})
+			i++
+			j++
+		case i < len(midA) && (j == len(midB) || lcs[i+1][j] >= lcs[i][j+1]):
+			result = append(result, diffLine{opDelete, midA[i]})
+			i++
+		default:
+			result = append(result, diffLine{opInsert, midB[j]})
+			j++
+		}
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		result = append(result, diffLine{opEqual, line})
+	}
+
+	return result
+}
+
+// hunks returns the start and end indices of the changed regions, including context lines.
+func hunks(lines []diffLine) [][2]int {
+	var result [][2]int
+
+	for i := 0; i < len(lines); i++ {
+		if lines[i].op == opEqual {
+			continue
+		}
+
+		start := max(i-diffContext, 0)
+
+		// Extend the hunk until there are more than twice the context of unchanged lines
+		end := i
+		for end < len(lines) {
+			if lines[end].op != opEqual {
+				end++
+
+				continue
+			}
+
+			run := end
+			for run < len(lines) && lines[run].op == opEqual {
+				run++
+			}
+
+			if run == len(lines) || run-end > 2*diffContext {
+				end = min(end+diffContext, len(lines))
+
+				break
+			}
+
+			end = run
+		}
+
+		result = append(result, [2]int{start, end})
+		i = end
+	}
+
+	return result
+}
+
+// writeHunk writes the lines between the bounds with a hunk header.
+func writeHunk(b *strings.Builder, lines []diffLine, bounds [2]int, color bool) {
+	hunk := lines[bounds[0]:bounds[1]]
+
+	// Line numbers are 1-based and count the lines before the hunk
+	oldStart, newStart := 1, 1
+
+	for _, line := range lines[:bounds[0]] {
+		if line.op != opInsert {
+			oldStart++
+		}
+
+		if line.op != opDelete {
+			newStart++
+		}
+	}
+
+	oldCount, newCount := 0, 0
+
+	for _, line := range hunk {
+		if line.op != opInsert {
+			oldCount++
+		}
+
+		if line.op != opDelete {
+			newCount++
+		}
+	}
+
+	// An empty range starts at the line before
+	if oldCount == 0 {
+		oldStart--
+	}
+
+	if newCount == 0 {
+		newStart--
+	}
+
+	header := fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+	writeColored(b, header, colorCyan, color)
+
+	for _, line := range hunk {
+		text := line.text
+		if !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+
+		switch line.op {
+		case opEqual:
+			b.WriteString(" " + text)
+		case opDelete:
+			writeColored(b, "-"+text, colorRed, color)
+		case opInsert:
+			writeColored(b, "+"+text, colorGreen, color)
+		}
+	}
+}
+
+func writeColored(b *strings.Builder, text, code string, color bool) {
+	if !color || code == "" {
+		b.WriteString(text)
+
+		return
+	}
+
+	b.WriteString(code + strings.TrimSuffix(text, "\n") + colorReset + "\n")
+}
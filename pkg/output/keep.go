@@ -0,0 +1,154 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Markers for hand-written sections in generated files.
+// Sections between them are carried over when the file is generated again.
+const (
+	keepStartMarker = "{/* docli:keep-start */}"
+	keepEndMarker   = "{/* docli:keep-end */}"
+)
+
+// keptRegion is a hand-written section of an existing file.
+type keptRegion struct {
+	// anchor is the generated line before the region, or empty at the start of the file.
+	anchor string
+	// occurrence counts which line with the anchor's text the region follows, starting at 1.
+	occurrence int
+	// lines has the region, including the blank lines between anchor and region.
+	lines []string
+}
+
+// mergeKept carries the hand-written regions of the existing file over into the rendered content.
+// Each region goes after the same generated line as in the existing file.
+// Regions whose line is no longer generated go at the end,
+// and mergeKept returns the lines they were anchored to.
+func mergeKept(existing, rendered []byte) ([]byte, []string, error) {
+	if !bytes.Contains(existing, []byte(keepStartMarker)) {
+		return rendered, nil, nil
+	}
+
+	regions, err := parseKept(string(existing))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	text, hasNewline := strings.CutSuffix(string(rendered), "\n")
+	lines := strings.Split(text, "\n")
+
+	var (
+		result   []string
+		inserted = make([]bool, len(regions))
+		seen     = map[string]int{}
+	)
+
+	insert := func(anchor string, occurrence int) {
+		for i, region := range regions {
+			if !inserted[i] && region.anchor == anchor && region.occurrence == occurrence {
+				result = append(result, region.lines...)
+				inserted[i] = true
+			}
+		}
+	}
+
+	insert("", 0)
+
+	for _, line := range lines {
+		result = append(result, line)
+
+		if key := strings.TrimSpace(line); key != "" {
+			seen[key]++
+			insert(key, seen[key])
+		}
+	}
+
+	var orphans []string
+
+	for i, region := range regions {
+		if inserted[i] {
+			continue
+		}
+
+		orphans = append(orphans, region.anchor)
+		result = append(result, "")
+		result = append(result, trimLeadingBlank(region.lines)...)
+	}
+
+	merged := strings.Join(result, "\n")
+	if hasNewline {
+		merged += "\n"
+	}
+
+	return []byte(merged), orphans, nil
+}
+
+// parseKept returns the hand-written regions of a file with their anchors.
+func parseKept(text string) ([]keptRegion, error) {
+	text = strings.TrimSuffix(text, "\n")
+	lines := strings.Split(text, "\n")
+
+	var (
+		regions    []keptRegion
+		anchor     string
+		occurrence int
+		seen       = map[string]int{}
+	)
+
+	for i := 0; i < len(lines); i++ {
+		key := strings.TrimSpace(lines[i])
+
+		if key == keepEndMarker {
+			return nil, fmt.Errorf("line %d: %s without %s", i+1, keepEndMarker, keepStartMarker)
+		}
+
+		if key != keepStartMarker {
+			if key != "" {
+				seen[key]++
+				anchor, occurrence = key, seen[key]
+			}
+
+			continue
+		}
+
+		// Keep the blank lines between the anchor and the region
+		start := i
+		for start > 0 && strings.TrimSpace(lines[start-1]) == "" {
+			start--
+		}
+
+		end := i + 1
+		for end < len(lines) && strings.TrimSpace(lines[end]) != keepEndMarker {
+			if strings.TrimSpace(lines[end]) == keepStartMarker {
+				return nil, fmt.Errorf("line %d: %s inside of another kept section", end+1, keepStartMarker)
+			}
+
+			end++
+		}
+
+		if end == len(lines) {
+			return nil, fmt.Errorf("line %d: %s without %s", i+1, keepStartMarker, keepEndMarker)
+		}
+
+		regions = append(regions, keptRegion{
+			anchor:     anchor,
+			occurrence: occurrence,
+			lines:      lines[start : end+1],
+		})
+
+		i = end
+	}
+
+	return regions, nil
+}
+
+func trimLeadingBlank(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+
+	return lines
+}
@@ -0,0 +1,122 @@
+package output
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeKept(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		existing    string
+		rendered    string
+		want        string
+		wantOrphans []string
+	}{
+		{
+			name:     "no kept sections",
+			existing: "---\ntitle: Old\n---\n\nOld text\n",
+			rendered: "---\ntitle: New\n---\n\nNew text\n",
+			want:     "---\ntitle: New\n---\n\nNew text\n",
+		},
+		{
+			name: "after frontmatter",
+			existing: "---\ntitle: Old\n---\n\n{/* docli:keep-start */}\n<Note>Hand-written</Note>\n" +
+				"{/* docli:keep-end */}\n\nOld text\n",
+			rendered: "---\ntitle: New\n---\n\nNew text\n",
+			want: "---\ntitle: New\n---\n\n{/* docli:keep-start */}\n<Note>Hand-written</Note>\n" +
+				"{/* docli:keep-end */}\n\nNew text\n",
+		},
+		{
+			name: "after a heading and at the end",
+			existing: "# Usage\n\n{/* docli:keep-start */}\nExample\n{/* docli:keep-end */}\n\nGenerated\n" +
+				"\n{/* docli:keep-start */}\nFooter\n{/* docli:keep-end */}\n",
+			rendered: "# Usage\n\nGenerated\n",
+			want: "# Usage\n\n{/* docli:keep-start */}\nExample\n{/* docli:keep-end */}\n\nGenerated\n" +
+				"\n{/* docli:keep-start */}\nFooter\n{/* docli:keep-end */}\n",
+		},
+		{
+			name:        "without anchor",
+			existing:    "# Old heading\n\n{/* docli:keep-start */}\nNotes\n{/* docli:keep-end */}\n",
+			rendered:    "# New heading\n\nText\n",
+			want:        "# New heading\n\nText\n\n{/* docli:keep-start */}\nNotes\n{/* docli:keep-end */}\n",
+			wantOrphans: []string{"# Old heading"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, orphans, err := mergeKept([]byte(tt.existing), []byte(tt.rendered))
+			if err != nil {
+				t.Fatalf("mergeKept() error = %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("mergeKept() =\n%s\nwant\n%s", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(orphans, tt.wantOrphans) {
+				t.Errorf("mergeKept() orphans = %q, want %q", orphans, tt.wantOrphans)
+			}
+
+			// Generating again doesn't change the merged file
+			again, _, err := mergeKept(got, []byte(tt.rendered))
+			if err != nil {
+				t.Fatalf("mergeKept() again error = %v", err)
+			}
+
+			if string(again) != string(got) {
+				t.Errorf("mergeKept() again =\n%s\nwant\n%s", again, got)
+			}
+		})
+	}
+}
+
+func TestMergeKeptRejectsUnclosedSections(t *testing.T) {
+	t.Parallel()
+
+	existing := "Text\n{/* docli:keep-start */}\nNotes\n"
+
+	_, _, err := mergeKept([]byte(existing), []byte("Text\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("mergeKept() error = %v, want error for line 2", err)
+	}
+}
+
+func TestWriteFileKeepsHandWrittenSections(t *testing.T) {
+	printer := newTestPrinter(t)
+
+	path := filepath.Join(t.TempDir(), "page.mdx")
+	existing := "# Title\n\n{/* docli:keep-start */}\nKeep me\n{/* docli:keep-end */}\n\nOld\n"
+
+	if err := os.WriteFile(path, []byte(existing), 0o600); err != nil {
+		t.Fatalf("write existing file: %v", err)
+	}
+
+	err := printer.WriteFile(path, func(w io.Writer) error {
+		_, err := io.WriteString(w, "# Title\n\nNew\n")
+
+		return err
+	})
+	if err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read file: %v", err)
+	}
+
+	want := "# Title\n\n{/* docli:keep-start */}\nKeep me\n{/* docli:keep-end */}\n\nNew\n"
+	if string(got) != want {
+		t.Errorf("file =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,118 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// manifestPrefix starts the names of the files listing the generated files in a directory.
+const manifestPrefix = ".docli-manifest"
+
+// ManifestFilename returns the name of the file listing the files that generator wrote in a directory.
+// Each generator owns its manifest, so generators that write to the same directory don't delete each other's files.
+// Files that aren't listed in the manifest are handwritten and never deleted.
+func ManifestFilename(generator string) string {
+	return fmt.Sprintf("%s.%s.json", manifestPrefix, generator)
+}
+
+type manifest struct {
+	Files []string `json:"files"`
+}
+
+// ReadManifest returns the names of the files listed in the manifest of generator in dir.
+// It returns no names if the directory has no manifest.
+func ReadManifest(dir, generator string) ([]string, error) {
+	path := filepath.Join(dir, ManifestFilename(generator))
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read manifest %s: %w", path, err)
+	}
+
+	var m manifest
+	if err := json.Unmarshal(contents, &m); err != nil {
+		return nil, fmt.Errorf("parse manifest %s: %w", path, err)
+	}
+
+	return m.Files, nil
+}
+
+// WriteManifest records the files of generator in the manifest of dir.
+func (p *Printer) WriteManifest(dir, generator string, files []string) error {
+	sorted := slices.Clone(files)
+	slices.Sort(sorted)
+
+	contents, err := json.MarshalIndent(manifest{Files: sorted}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode manifest for %s: %w", dir, err)
+	}
+
+	return p.WriteFile(filepath.Join(dir, ManifestFilename(generator)), func(w io.Writer) error {
+		_, err := fmt.Fprintf(w, "%s\n", contents)
+
+		return err
+	})
+}
+
+// Prune deletes the files of generator in dir that aren't in keep.
+// Only files listed in the generator's manifest are considered.
+func (p *Printer) Prune(dir, generator string, keep []string) error {
+	generated, err := ReadManifest(dir, generator)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range generated {
+		if slices.Contains(keep, name) {
+			continue
+		}
+
+		// Don't follow manifest entries outside of the directory
+		if name != filepath.Base(name) || strings.HasPrefix(name, manifestPrefix) {
+			return fmt.Errorf("invalid manifest entry %q in %s", name, dir)
+		}
+
+		path := filepath.Join(dir, name)
+		start := time.Now()
+
+		if _, err := os.Stat(path); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			return fmt.Errorf("stat %s: %w", path, err)
+		}
+
+		if p.check {
+			p.markOutdated(path)
+
+			continue
+		}
+
+		if p.dryRun {
+			p.Infof("Dry run: would delete %s\n", path)
+
+			continue
+		}
+
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("delete %s: %w", path, err)
+		}
+
+		p.Infof("Deleted stale file: %s\n", path)
+		p.record(path, StatusDeleted, start)
+	}
+
+	return nil
+}
@@ -1,24 +1,83 @@
 package output
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 const (
 	FlagVerbose = "verbose"
 	FlagQuiet   = "quiet"
 	FlagDryRun  = "dry-run"
+	FlagCheck   = "check"
+	FlagDiff    = "diff"
+	// FlagReport selects the format of the run report.
+	FlagReport = "report"
+	// FlagReportFile writes the run report to a file instead of the standard output.
+	FlagReportFile = "report-file"
 )
 
+// ReportFormatJSON is the only supported format for run reports.
+const ReportFormatJSON = "json"
+
+// Status describes what happened to an output file.
+type Status string
+
+const (
+	StatusCreated   Status = "created"
+	StatusUpdated   Status = "updated"
+	StatusUnchanged Status = "unchanged"
+	StatusDeleted   Status = "deleted"
+	// StatusSkipped is for files that aren't written in dry-run mode.
+	StatusSkipped Status = "skipped"
+)
+
+// FileResult holds the outcome for a single output file.
+type FileResult struct {
+	Path       string `json:"path"`
+	Status     Status `json:"status"`
+	DurationMs int64  `json:"durationMs"`
+}
+
 type Printer struct {
 	cmd     *cobra.Command
 	verbose bool
 	quiet   bool
 	dryRun  bool
+	check   bool
+	diff    bool
+	// report is the format of the run report, or empty for no report.
+	report     string
+	reportFile string
+	started    time.Time
+	inputs     []string
+	warnings   []string
+	findings   []string
+	// results lists the outcome for each file written or deleted in this run.
+	results []FileResult
+	// outdated lists the files that don't match the generated content in check mode.
+	outdated []string
+}
+
+// AddFlags adds the global flags that New reads to flags.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.BoolP(FlagVerbose, "v", false, "Enable verbose output")
+	flags.BoolP(FlagQuiet, "q", false, "Suppress non-error output")
+	flags.Bool(FlagDryRun, false, "Preview actions without writing files")
+	flags.Bool(FlagCheck, false, "Fail if generated files are out of date, without writing files")
+	flags.Bool(FlagDiff, false, "Show the changes to generated files as diffs, without writing files")
+	flags.String(FlagReport, "", "Print a run report in this format (json)")
+	flags.String(FlagReportFile, "", "Write a JSON run report to this file")
 }
 
 func New(cmd *cobra.Command) (*Printer, error) {
@@ -37,15 +96,49 @@ func New(cmd *cobra.Command) (*Printer, error) {
 		return nil, err
 	}
 
+	check, err := cmd.Flags().GetBool(FlagCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := cmd.Flags().GetBool(FlagDiff)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := cmd.Flags().GetString(FlagReport)
+	if err != nil {
+		return nil, err
+	}
+
+	reportFile, err := cmd.Flags().GetString(FlagReportFile)
+	if err != nil {
+		return nil, err
+	}
+
 	if verbose && quiet {
 		return nil, fmt.Errorf("cannot use --%s and --%s together", FlagVerbose, FlagQuiet)
 	}
 
+	if reportFile != "" && report == "" {
+		report = ReportFormatJSON
+	}
+
+	if report != "" && report != ReportFormatJSON {
+		return nil, fmt.Errorf("unsupported report format %q, use %q", report, ReportFormatJSON)
+	}
+
+	// Check and diff modes never write files
 	return &Printer{
-		cmd:     cmd,
-		verbose: verbose,
-		quiet:   quiet,
-		dryRun:  dryRun,
+		cmd:        cmd,
+		verbose:    verbose,
+		quiet:      quiet,
+		dryRun:     dryRun || check || diff,
+		check:      check,
+		diff:       diff,
+		report:     report,
+		reportFile: reportFile,
+		started:    time.Now(),
 	}, nil
 }
 
@@ -54,7 +147,7 @@ func (p *Printer) Infof(format string, args ...any) {
 		return
 	}
 
-	p.cmd.Printf(format, args...)
+	p.printf(format, args...)
 }
 
 func (p *Printer) Verbosef(format string, args ...any) {
@@ -62,6 +155,62 @@ func (p *Printer) Verbosef(format string, args ...any) {
 		return
 	}
 
+	p.printf(format, args...)
+}
+
+// Warnf prints a warning and adds it to the run report.
+func (p *Printer) Warnf(format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	p.warnings = append(p.warnings, strings.TrimSpace(message))
+
+	p.cmd.PrintErrf("Warning: %s", message)
+}
+
+// Findingf prints a problem that the command found, such as a lint finding,
+// and adds it to the run report.
+// Findings are the result of the command, so they're printed even in quiet mode.
+func (p *Printer) Findingf(format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	p.findings = append(p.findings, strings.TrimSpace(message))
+
+	p.printf(format, args...)
+}
+
+// Stdout returns the standard output for content that a command prints instead of writing a file.
+// It returns an error if the standard output is reserved for the run report.
+func (p *Printer) Stdout(content string) (io.Writer, error) {
+	if p.reportsToStdout() {
+		return nil, fmt.Errorf(
+			"cannot print the %s to the standard output with the run report, use --%s",
+			content,
+			FlagReportFile,
+		)
+	}
+
+	return p.cmd.OutOrStdout(), nil
+}
+
+// reportsToStdout returns true if the run report is printed to the standard output.
+func (p *Printer) reportsToStdout() bool {
+	return p.report != "" && p.reportFile == ""
+}
+
+// AddInput adds a file that the command reads to the run report.
+func (p *Printer) AddInput(path string) {
+	if !slices.Contains(p.inputs, path) {
+		p.inputs = append(p.inputs, path)
+	}
+}
+
+// printf prints messages to the standard output,
+// unless the standard output is reserved for the run report.
+func (p *Printer) printf(format string, args ...any) {
+	if p.reportsToStdout() {
+		p.cmd.PrintErrf(format, args...)
+
+		return
+	}
+
 	p.cmd.Printf(format, args...)
 }
 
@@ -81,31 +230,239 @@ func (p *Printer) IsDryRun() bool {
 	return p.dryRun
 }
 
+// Finish completes the run of a command and prints a summary of the written files.
+// It takes the error of the run, if any, and writes the report with it,
+// so that failed runs have a report too.
+// In check mode, it returns an error if any file is out of date.
+func (p *Printer) Finish(err error) error {
+	if !p.dryRun && len(p.results) > 0 {
+		p.Infof(
+			"Summary: %d created, %d updated, %d unchanged, %d deleted\n",
+			p.count(StatusCreated),
+			p.count(StatusUpdated),
+			p.count(StatusUnchanged),
+			p.count(StatusDeleted),
+		)
+	}
+
+	if err == nil && p.check && len(p.outdated) > 0 {
+		err = fmt.Errorf(
+			"%d generated files are out of date, run the command without --%s to update them",
+			len(p.outdated),
+			FlagCheck,
+		)
+	}
+
+	if reportErr := p.writeReport(err); reportErr != nil {
+		return errors.Join(err, reportErr)
+	}
+
+	return err
+}
+
+// WriteFile renders the content with write and writes it to path.
+// Files whose content hasn't changed aren't rewritten.
+// Hand-written sections between docli:keep-start and docli:keep-end comments
+// in the existing file are carried over into the new content.
 func (p *Printer) WriteFile(path string, write func(io.Writer) error) error {
-	if p.dryRun {
+	start := time.Now()
+
+	if p.dryRun && !p.check && !p.diff {
 		if err := write(io.Discard); err != nil {
 			return fmt.Errorf("render dry-run %s: %w", path, err)
 		}
 
 		p.Infof("Dry run: would write %s\n", path)
+		p.record(path, StatusSkipped, start)
+
+		return nil
+	}
+
+	var rendered bytes.Buffer
+	if err := write(&rendered); err != nil {
+		return fmt.Errorf("render %s: %w", path, err)
+	}
+
+	existing, exists, err := readExisting(path)
+	if err != nil {
+		return err
+	}
+
+	contents, orphans, err := mergeKept(existing, rendered.Bytes())
+	if err != nil {
+		return fmt.Errorf("keep hand-written sections of %s: %w", path, err)
+	}
+
+	for _, anchor := range orphans {
+		p.Warnf("Moved a hand-written section to the end of %s: line %q no longer exists\n", path, anchor)
+	}
+
+	if p.dryRun {
+		p.compareFile(path, existing, exists, contents)
+		p.record(path, StatusSkipped, start)
+
+		return nil
+	}
+
+	if exists && bytes.Equal(existing, contents) {
+		p.record(path, StatusUnchanged, start)
+		p.Verbosef("Unchanged: %s\n", path)
 
 		return nil
 	}
 
-	output, err := os.Create(path)
+	if err := writeAtomic(path, contents); err != nil {
+		return err
+	}
+
+	if exists {
+		p.record(path, StatusUpdated, start)
+		p.Verbosef("Updated: %s\n", path)
+	} else {
+		p.record(path, StatusCreated, start)
+		p.Verbosef("Created: %s\n", path)
+	}
+
+	return nil
+}
+
+// writeAtomic writes the contents to a temporary file in the same directory
+// and renames it to path, so that path never holds partially written content.
+func writeAtomic(path string, contents []byte) error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
 	if err != nil {
-		return fmt.Errorf("create %s: %w", path, err)
+		return fmt.Errorf("create temporary file for %s: %w", path, err)
 	}
 
-	if err := write(output); err != nil {
-		_ = output.Close()
+	// Clean up the temporary file if anything fails before the rename
+	renamed := false
+
+	defer func() {
+		if !renamed {
+			_ = os.Remove(temp.Name())
+		}
+	}()
+
+	if _, err := temp.Write(contents); err != nil {
+		_ = temp.Close()
 
 		return fmt.Errorf("write %s: %w", path, err)
 	}
 
-	if err := output.Close(); err != nil {
+	if err := temp.Chmod(mode); err != nil {
+		_ = temp.Close()
+
+		return fmt.Errorf("set permissions for %s: %w", path, err)
+	}
+
+	if err := temp.Close(); err != nil {
 		return fmt.Errorf("close %s: %w", path, err)
 	}
 
+	if err := os.Rename(temp.Name(), path); err != nil {
+		return fmt.Errorf("rename %s: %w", path, err)
+	}
+
+	renamed = true
+
 	return nil
 }
+
+// record adds the outcome for a file to the results of the run.
+func (p *Printer) record(path string, status Status, start time.Time) {
+	p.results = append(p.results, FileResult{
+		Path:       path,
+		Status:     status,
+		DurationMs: time.Since(start).Milliseconds(),
+	})
+}
+
+// count returns the number of files with the given status.
+func (p *Printer) count(status Status) int {
+	n := 0
+
+	for _, result := range p.results {
+		if result.Status == status {
+			n++
+		}
+	}
+
+	return n
+}
+
+// readExisting returns the contents of the file at path and whether it exists.
+func readExisting(path string) ([]byte, bool, error) {
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, false, nil
+		}
+
+		return nil, false, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	return existing, true, nil
+}
+
+// compareFile compares the rendered content with the file on disk
+// and prints the result for check and diff modes.
+func (p *Printer) compareFile(path string, existing []byte, exists bool, rendered []byte) {
+	if exists && bytes.Equal(existing, rendered) {
+		if p.diff {
+			p.Infof("Unchanged: %s\n", path)
+		} else {
+			p.Verbosef("Up to date: %s\n", path)
+		}
+
+		return
+	}
+
+	if p.diff {
+		oldName := path
+		if !exists {
+			oldName = "/dev/null"
+		}
+
+		p.printf("%s", unifiedDiff(oldName, path, string(existing), string(rendered), p.useColor()))
+	}
+
+	if p.check {
+		p.markOutdated(path)
+	}
+}
+
+// markOutdated records a file that would change and prints its path.
+// The paths are the result of the check, so they're printed even in quiet mode.
+func (p *Printer) markOutdated(path string) {
+	p.outdated = append(p.outdated, path)
+	p.printf("Out of date: %s\n", path)
+}
+
+// useColor returns true if the output is a terminal and colors aren't disabled.
+func (p *Printer) useColor() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+
+	out := p.cmd.OutOrStdout()
+	if p.reportsToStdout() {
+		out = p.cmd.ErrOrStderr()
+	}
+
+	file, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
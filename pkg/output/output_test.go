@@ -6,7 +6,9 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -15,9 +17,7 @@ func TestNewRejectsVerboseQuiet(t *testing.T) {
 	cmd := &cobra.Command{}
 	cmd.SetOut(&bytes.Buffer{})
 	cmd.SetErr(&bytes.Buffer{})
-	cmd.Flags().BoolP(FlagVerbose, "v", false, "verbose")
-	cmd.Flags().BoolP(FlagQuiet, "q", false, "quiet")
-	cmd.Flags().Bool(FlagDryRun, false, "dry run")
+	AddFlags(cmd.Flags())
 
 	if err := cmd.Flags().Set(FlagVerbose, "true"); err != nil {
 		t.Fatalf("set verbose: %v", err)
@@ -37,9 +37,7 @@ func TestWriteFileDryRunSkipsCreation(t *testing.T) {
 	cmd := &cobra.Command{}
 	cmd.SetOut(&bytes.Buffer{})
 	cmd.SetErr(&bytes.Buffer{})
-	cmd.Flags().BoolP(FlagVerbose, "v", false, "verbose")
-	cmd.Flags().BoolP(FlagQuiet, "q", false, "quiet")
-	cmd.Flags().Bool(FlagDryRun, false, "dry run")
+	AddFlags(cmd.Flags())
 
 	if err := cmd.Flags().Set(FlagDryRun, "true"); err != nil {
 		t.Fatalf("set dry run: %v", err)
@@ -73,9 +71,7 @@ func TestWriteFileWrapsWriteErrors(t *testing.T) {
 	cmd := &cobra.Command{}
 	cmd.SetOut(&bytes.Buffer{})
 	cmd.SetErr(&bytes.Buffer{})
-	cmd.Flags().BoolP(FlagVerbose, "v", false, "verbose")
-	cmd.Flags().BoolP(FlagQuiet, "q", false, "quiet")
-	cmd.Flags().Bool(FlagDryRun, false, "dry run")
+	AddFlags(cmd.Flags())
 
 	printer, err := New(cmd)
 	if err != nil {
@@ -98,3 +94,380 @@ func TestWriteFileWrapsWriteErrors(t *testing.T) {
 		t.Fatalf("expected wrapped error, got %v", err)
 	}
 }
+
+func TestPruneDeletesOnlyStaleGeneratedFiles(t *testing.T) {
+	printer := newTestPrinter(t)
+	dir := t.TempDir()
+
+	for _, name := range []string{"kept.mdx", "stale.mdx", "handwritten.mdx"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	if err := printer.WriteManifest(dir, "openapi", []string{"stale.mdx", "kept.mdx"}); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+
+	if err := printer.Prune(dir, "openapi", []string{"kept.mdx"}); err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "stale.mdx")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected stale.mdx to be deleted, got %v", err)
+	}
+
+	for _, name := range []string{"kept.mdx", "handwritten.mdx"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Fatalf("expected %s to be kept: %v", name, err)
+		}
+	}
+}
+
+func TestPruneDryRunKeepsFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "stale.mdx")
+
+	if err := os.WriteFile(path, []byte("stale"), 0o600); err != nil {
+		t.Fatalf("write stale file: %v", err)
+	}
+
+	if err := newTestPrinter(t).WriteManifest(dir, "openapi", []string{"stale.mdx"}); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+
+	if err := newTestPrinter(t, FlagDryRun).Prune(dir, "openapi", nil); err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected stale.mdx to be kept in dry run: %v", err)
+	}
+}
+
+func TestPruneRejectsManifestEntriesOutsideDirectory(t *testing.T) {
+	dir := t.TempDir()
+	manifest := []byte(`{"files": ["../outside.mdx"]}`)
+
+	if err := os.WriteFile(filepath.Join(dir, ManifestFilename("openapi")), manifest, 0o600); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+
+	if err := newTestPrinter(t).Prune(dir, "openapi", nil); err == nil {
+		t.Fatal("expected error for manifest entry outside the directory")
+	}
+}
+
+func TestWriteFileCheckReportsOutdatedFiles(t *testing.T) {
+	cmd := newTestCommand(t, FlagCheck)
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	printer, err := New(cmd)
+	if err != nil {
+		t.Fatalf("new printer: %v", err)
+	}
+
+	dir := t.TempDir()
+	current := filepath.Join(dir, "current.mdx")
+	changed := filepath.Join(dir, "changed.mdx")
+	missing := filepath.Join(dir, "missing.mdx")
+
+	for _, path := range []string{current, changed} {
+		if err := os.WriteFile(path, []byte("old"), 0o600); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+
+	writes := map[string]string{current: "old", changed: "new", missing: "new"}
+	for path, content := range writes {
+		err := printer.WriteFile(path, func(w io.Writer) error {
+			_, err := io.WriteString(w, content)
+
+			return err
+		})
+		if err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+
+	if got, err := os.ReadFile(changed); err != nil || string(got) != "old" {
+		t.Fatalf("expected changed.mdx to be left alone, got %q (%v)", got, err)
+	}
+
+	if _, err := os.Stat(missing); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected missing.mdx not to be created, got %v", err)
+	}
+
+	if err := printer.Finish(nil); err == nil {
+		t.Fatal("expected Finish to fail with outdated files")
+	}
+
+	for _, path := range []string{changed, missing} {
+		if !strings.Contains(out.String(), "Out of date: "+path) {
+			t.Fatalf("expected output to list %s, got:\n%s", path, out.String())
+		}
+	}
+
+	if strings.Contains(out.String(), current) {
+		t.Fatalf("expected output not to list up-to-date file, got:\n%s", out.String())
+	}
+}
+
+func TestFinishCheckPassesWhenUpToDate(t *testing.T) {
+	printer := newTestPrinter(t, FlagCheck)
+	path := filepath.Join(t.TempDir(), "out.mdx")
+
+	if err := os.WriteFile(path, []byte("content"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	err := printer.WriteFile(path, func(w io.Writer) error {
+		_, err := io.WriteString(w, "content")
+
+		return err
+	})
+	if err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	if err := printer.Finish(nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestWriteFileDiffPrintsChanges(t *testing.T) {
+	cmd := newTestCommand(t, FlagDiff)
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	printer, err := New(cmd)
+	if err != nil {
+		t.Fatalf("new printer: %v", err)
+	}
+
+	dir := t.TempDir()
+	changed := filepath.Join(dir, "changed.mdx")
+	unchanged := filepath.Join(dir, "unchanged.mdx")
+	created := filepath.Join(dir, "created.mdx")
+
+	if err := os.WriteFile(changed, []byte("title\nold line\n"), 0o600); err != nil {
+		t.Fatalf("write changed.mdx: %v", err)
+	}
+
+	if err := os.WriteFile(unchanged, []byte("same\n"), 0o600); err != nil {
+		t.Fatalf("write unchanged.mdx: %v", err)
+	}
+
+	writes := []struct{ path, content string }{
+		{changed, "title\nnew line\n"},
+		{unchanged, "same\n"},
+		{created, "fresh\n"},
+	}
+
+	for _, write := range writes {
+		err := printer.WriteFile(write.path, func(w io.Writer) error {
+			_, err := io.WriteString(w, write.content)
+
+			return err
+		})
+		if err != nil {
+			t.Fatalf("write %s: %v", write.path, err)
+		}
+	}
+
+	if got, err := os.ReadFile(changed); err != nil || string(got) != "title\nold line\n" {
+		t.Fatalf("expected changed.mdx to be left alone, got %q (%v)", got, err)
+	}
+
+	if _, err := os.Stat(created); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected created.mdx not to be created, got %v", err)
+	}
+
+	for _, want := range []string{
+		"--- " + changed + "\n+++ " + changed + "\n@@ -1,2 +1,2 @@\n title\n-old line\n+new line\n",
+		"--- /dev/null\n+++ " + created + "\n@@ -0,0 +1,1 @@\n+fresh\n",
+		"Unchanged: " + unchanged,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestWriteFileSkipsUnchangedFiles(t *testing.T) {
+	cmd := newTestCommand(t)
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	printer, err := New(cmd)
+	if err != nil {
+		t.Fatalf("new printer: %v", err)
+	}
+
+	dir := t.TempDir()
+	unchanged := filepath.Join(dir, "unchanged.mdx")
+	updated := filepath.Join(dir, "updated.mdx")
+	created := filepath.Join(dir, "created.mdx")
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	for _, path := range []string{unchanged, updated} {
+		if err := os.WriteFile(path, []byte("old"), 0o600); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+
+		if err := os.Chtimes(path, past, past); err != nil {
+			t.Fatalf("set times for %s: %v", path, err)
+		}
+	}
+
+	writes := []struct{ path, content string }{
+		{unchanged, "old"},
+		{updated, "new"},
+		{created, "new"},
+	}
+
+	for _, write := range writes {
+		err := printer.WriteFile(write.path, func(w io.Writer) error {
+			_, err := io.WriteString(w, write.content)
+
+			return err
+		})
+		if err != nil {
+			t.Fatalf("write %s: %v", write.path, err)
+		}
+	}
+
+	info, err := os.Stat(unchanged)
+	if err != nil {
+		t.Fatalf("stat unchanged.mdx: %v", err)
+	}
+
+	if !info.ModTime().Equal(past) {
+		t.Fatalf("expected unchanged.mdx not to be rewritten, modified at %v", info.ModTime())
+	}
+
+	for _, path := range []string{updated, created} {
+		if got, err := os.ReadFile(path); err != nil || string(got) != "new" {
+			t.Fatalf("expected %s to contain new content, got %q (%v)", path, got, err)
+		}
+	}
+
+	if err := printer.Finish(nil); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+
+	want := "Summary: 1 created, 1 updated, 1 unchanged, 0 deleted"
+	if !strings.Contains(out.String(), want) {
+		t.Fatalf("expected output to contain %q, got:\n%s", want, out.String())
+	}
+}
+
+func TestWriteFileKeepsOriginalOnFailure(t *testing.T) {
+	printer := newTestPrinter(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.mdx")
+
+	if err := os.WriteFile(path, []byte("original"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	err := printer.WriteFile(path, func(w io.Writer) error {
+		if _, err := io.WriteString(w, "partial"); err != nil {
+			return err
+		}
+
+		return errors.New("template failed")
+	})
+	if err == nil {
+		t.Fatal("expected error from write")
+	}
+
+	if got, err := os.ReadFile(path); err != nil || string(got) != "original" {
+		t.Fatalf("expected original content to be kept, got %q (%v)", got, err)
+	}
+
+	assertNoTempFiles(t, dir)
+}
+
+func TestWriteFileReplacesFileAtomically(t *testing.T) {
+	printer := newTestPrinter(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.mdx")
+
+	if err := os.WriteFile(path, []byte("original"), 0o640); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	err := printer.WriteFile(path, func(w io.Writer) error {
+		_, err := io.WriteString(w, "updated")
+
+		return err
+	})
+	if err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat file: %v", err)
+	}
+
+	if info.Mode().Perm() != 0o640 {
+		t.Fatalf("expected permissions to be kept, got %v", info.Mode().Perm())
+	}
+
+	if got, err := os.ReadFile(path); err != nil || string(got) != "updated" {
+		t.Fatalf("expected updated content, got %q (%v)", got, err)
+	}
+
+	assertNoTempFiles(t, dir)
+}
+
+func assertNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".tmp") {
+			t.Fatalf("expected no temporary files, found %s", entry.Name())
+		}
+	}
+}
+
+// newTestCommand returns a command with the global flags and the given boolean flags enabled.
+func newTestCommand(t *testing.T, flags ...string) *cobra.Command {
+	t.Helper()
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	AddFlags(cmd.Flags())
+
+	for _, flag := range flags {
+		if err := cmd.Flags().Set(flag, "true"); err != nil {
+			t.Fatalf("set %s: %v", flag, err)
+		}
+	}
+
+	return cmd
+}
+
+// newTestPrinter returns a printer with the given boolean flags enabled.
+func newTestPrinter(t *testing.T, flags ...string) *Printer {
+	t.Helper()
+
+	printer, err := New(newTestCommand(t, flags...))
+	if err != nil {
+		t.Fatalf("new printer: %v", err)
+	}
+
+	return printer
+}
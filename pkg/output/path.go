@@ -0,0 +1,54 @@
+package output
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// SafeName returns the name as a single element of an output path.
+// Names come from specs and data files, such as operation IDs or package names,
+// so path separators and control characters are replaced with dashes.
+// Empty names and the names `.` and `..` are refused with an error that names the source.
+func SafeName(name, source string) (string, error) {
+	safe := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || unicode.IsControl(r) {
+			return '-'
+		}
+
+		return r
+	}, name)
+
+	safe = strings.TrimSpace(safe)
+	if safe == "" || safe == "." || safe == ".." {
+		return "", fmt.Errorf("%s: invalid output name %q", source, name)
+	}
+
+	return safe, nil
+}
+
+// ResolvePath returns the path of the names in the output directory root.
+// Each name is a single path element, see SafeName.
+// It returns an error that names the source if the path is outside of root.
+func ResolvePath(root, source string, names ...string) (string, error) {
+	elems := []string{root}
+
+	for _, name := range names {
+		safe, err := SafeName(name, source)
+		if err != nil {
+			return "", err
+		}
+
+		elems = append(elems, safe)
+	}
+
+	result := filepath.Join(elems...)
+
+	rel, err := filepath.Rel(root, result)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s: output path %s is outside of %s", source, result, root)
+	}
+
+	return result, nil
+}
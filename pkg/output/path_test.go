@@ -0,0 +1,77 @@
+package output
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolvePath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		names   []string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "file in root",
+			names: []string{"search-single-index.mdx"},
+			want:  filepath.Join("out", "search-single-index.mdx"),
+		},
+		{
+			name:  "file in subdirectory",
+			names: []string{"search", "search-single-index.mdx"},
+			want:  filepath.Join("out", "search", "search-single-index.mdx"),
+		},
+		{
+			name:  "separators are replaced",
+			names: []string{"../../etc/passwd"},
+			want:  filepath.Join("out", "..-..-etc-passwd"),
+		},
+		{
+			name:  "backslashes and control characters are replaced",
+			names: []string{`..\evil` + "\n.mdx"},
+			want:  filepath.Join("out", "..-evil-.mdx"),
+		},
+		{
+			name:    "parent directory",
+			names:   []string{".."},
+			wantErr: `package foo: invalid output name ".."`,
+		},
+		{
+			name:    "current directory",
+			names:   []string{"search", "."},
+			wantErr: `package foo: invalid output name "."`,
+		},
+		{
+			name:    "empty name",
+			names:   []string{" "},
+			wantErr: `package foo: invalid output name " "`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ResolvePath("out", "package foo", tt.names...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ResolvePath(%q) error = %v, want %q", tt.names, err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ResolvePath(%q) error = %v", tt.names, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("ResolvePath(%q) = %q, want %q", tt.names, got, tt.want)
+			}
+		})
+	}
+}
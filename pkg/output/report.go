@@ -0,0 +1,103 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Report is the machine-readable summary of a command run.
+type Report struct {
+	Command    string         `json:"command"`
+	Status     RunStatus      `json:"status"`
+	Error      string         `json:"error,omitempty"`
+	DryRun     bool           `json:"dryRun"`
+	Inputs     []string       `json:"inputs"`
+	Outputs    []FileResult   `json:"outputs"`
+	Summary    map[Status]int `json:"summary"`
+	Warnings   []string       `json:"warnings"`
+	Findings   []string       `json:"findings"`
+	StartedAt  time.Time      `json:"startedAt"`
+	DurationMs int64          `json:"durationMs"`
+}
+
+// RunStatus is the outcome of a command run.
+type RunStatus string
+
+const (
+	RunSucceeded RunStatus = "succeeded"
+	RunFailed    RunStatus = "failed"
+)
+
+// Report returns the report for the command run so far.
+// If the run failed with err, the report has the failed status and the error message.
+func (p *Printer) Report(err error) Report {
+	report := Report{
+		Command:    p.cmd.CommandPath(),
+		Status:     RunSucceeded,
+		DryRun:     p.dryRun,
+		Inputs:     p.inputs,
+		Outputs:    p.results,
+		Summary:    make(map[Status]int),
+		Warnings:   p.warnings,
+		Findings:   p.findings,
+		StartedAt:  p.started,
+		DurationMs: time.Since(p.started).Milliseconds(),
+	}
+
+	// Use empty lists instead of null for consumers
+	if report.Inputs == nil {
+		report.Inputs = []string{}
+	}
+
+	if report.Outputs == nil {
+		report.Outputs = []FileResult{}
+	}
+
+	if report.Warnings == nil {
+		report.Warnings = []string{}
+	}
+
+	if report.Findings == nil {
+		report.Findings = []string{}
+	}
+
+	if err != nil {
+		report.Status = RunFailed
+		report.Error = err.Error()
+	}
+
+	for _, result := range p.results {
+		report.Summary[result.Status]++
+	}
+
+	return report
+}
+
+// writeReport writes the run report if requested.
+// Without a report file, it writes the report to the standard output.
+func (p *Printer) writeReport(runErr error) error {
+	if p.report == "" {
+		return nil
+	}
+
+	contents, err := json.MarshalIndent(p.Report(runErr), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode report: %w", err)
+	}
+
+	contents = append(contents, '\n')
+
+	if p.reportFile == "" {
+		_, err := p.cmd.OutOrStdout().Write(contents)
+
+		return err
+	}
+
+	if err := os.WriteFile(p.reportFile, contents, 0o644); err != nil {
+		return fmt.Errorf("write report %s: %w", p.reportFile, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFinishWritesJSONReport(t *testing.T) {
+	cmd := newTestCommand(t)
+
+	var out, errOut bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+
+	if err := cmd.Flags().Set(FlagReport, ReportFormatJSON); err != nil {
+		t.Fatalf("set report: %v", err)
+	}
+
+	printer, err := New(cmd)
+	if err != nil {
+		t.Fatalf("new printer: %v", err)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.mdx")
+
+	printer.AddInput("specs/search.yml")
+	printer.AddInput("specs/search.yml")
+	printer.Infof("Writing output in: %s\n", dir)
+	printer.Warnf("operation %s has no summary\n", "getObject")
+
+	err = printer.WriteFile(path, func(w io.Writer) error {
+		_, err := io.WriteString(w, "content")
+
+		return err
+	})
+	if err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	if err := printer.Finish(nil); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+
+	var report Report
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("expected only the JSON report on stdout: %v\n%s", err, out.String())
+	}
+
+	if report.Status != RunSucceeded || report.Error != "" {
+		t.Fatalf("Status = %q, Error = %q, want succeeded without error", report.Status, report.Error)
+	}
+
+	if len(report.Inputs) != 1 || report.Inputs[0] != "specs/search.yml" {
+		t.Fatalf("Inputs = %v, want [specs/search.yml]", report.Inputs)
+	}
+
+	if len(report.Outputs) != 1 || report.Outputs[0].Path != path ||
+		report.Outputs[0].Status != StatusCreated {
+		t.Fatalf("Outputs = %+v, want one created file %s", report.Outputs, path)
+	}
+
+	if report.Summary[StatusCreated] != 1 {
+		t.Fatalf("Summary = %v, want 1 created", report.Summary)
+	}
+
+	if len(report.Warnings) != 1 || report.Warnings[0] != "operation getObject has no summary" {
+		t.Fatalf("Warnings = %q, want one warning", report.Warnings)
+	}
+
+	if !bytes.Contains(errOut.Bytes(), []byte("Writing output in:")) {
+		t.Fatalf("expected messages on stderr, got:\n%s", errOut.String())
+	}
+}
+
+func TestFinishWritesReportFile(t *testing.T) {
+	cmd := newTestCommand(t, FlagDryRun)
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+
+	if err := cmd.Flags().Set(FlagReportFile, reportFile); err != nil {
+		t.Fatalf("set report file: %v", err)
+	}
+
+	printer, err := New(cmd)
+	if err != nil {
+		t.Fatalf("new printer: %v", err)
+	}
+
+	err = printer.WriteFile(filepath.Join(t.TempDir(), "out.mdx"), func(w io.Writer) error {
+		_, err := io.WriteString(w, "content")
+
+		return err
+	})
+	if err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	if err := printer.Finish(nil); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+
+	contents, err := os.ReadFile(reportFile)
+	if err != nil {
+		t.Fatalf("read report: %v", err)
+	}
+
+	var report Report
+	if err := json.Unmarshal(contents, &report); err != nil {
+		t.Fatalf("parse report: %v", err)
+	}
+
+	if !report.DryRun || len(report.Outputs) != 1 || report.Outputs[0].Status != StatusSkipped {
+		t.Fatalf("report = %+v, want one skipped file in dry run", report)
+	}
+}
+
+func TestFinishWritesReportOnFailure(t *testing.T) {
+	cmd := newTestCommand(t)
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+
+	if err := cmd.Flags().Set(FlagReportFile, reportFile); err != nil {
+		t.Fatalf("set report file: %v", err)
+	}
+
+	printer, err := New(cmd)
+	if err != nil {
+		t.Fatalf("new printer: %v", err)
+	}
+
+	runErr := errors.New("parse specs/search.yml: invalid spec")
+	if err := printer.Finish(runErr); !errors.Is(err, runErr) {
+		t.Fatalf("finish: got %v, want %v", err, runErr)
+	}
+
+	contents, err := os.ReadFile(reportFile)
+	if err != nil {
+		t.Fatalf("read report: %v", err)
+	}
+
+	var report Report
+	if err := json.Unmarshal(contents, &report); err != nil {
+		t.Fatalf("parse report: %v", err)
+	}
+
+	if report.Status != RunFailed || report.Error != runErr.Error() {
+		t.Fatalf("report = %+v, want failed status with the error", report)
+	}
+}
+
+func TestFindingsGoToReportOnStdout(t *testing.T) {
+	cmd := newTestCommand(t, FlagQuiet)
+
+	var out, errOut bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+
+	if err := cmd.Flags().Set(FlagReport, ReportFormatJSON); err != nil {
+		t.Fatalf("set report: %v", err)
+	}
+
+	printer, err := New(cmd)
+	if err != nil {
+		t.Fatalf("new printer: %v", err)
+	}
+
+	printer.Findingf("%s:%d:%d: %s\n", "specs/search.yml", 3, 5, "error: missing summary")
+
+	if _, err := printer.Stdout("changelog entry"); err == nil {
+		t.Fatal("expected an error for content on the standard output with the report")
+	}
+
+	if err := printer.Finish(nil); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+
+	var report Report
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("expected only the JSON report on stdout: %v\n%s", err, out.String())
+	}
+
+	want := "specs/search.yml:3:5: error: missing summary"
+	if len(report.Findings) != 1 || report.Findings[0] != want {
+		t.Fatalf("Findings = %q, want [%q]", report.Findings, want)
+	}
+
+	// Findings are printed in quiet mode
+	if errOut.String() != want+"\n" {
+		t.Fatalf("stderr = %q, want the finding", errOut.String())
+	}
+}
+
+func TestNewRejectsUnknownReportFormat(t *testing.T) {
+	cmd := newTestCommand(t)
+
+	if err := cmd.Flags().Set(FlagReport, "xml"); err != nil {
+		t.Fatalf("set report: %v", err)
+	}
+
+	if _, err := New(cmd); err == nil {
+		t.Fatal("expected error for unsupported report format")
+	}
+}